@@ -0,0 +1,121 @@
+package csgolog
+
+import (
+	"time"
+)
+
+// BombAction is an action on the bomb which takes time to complete
+type BombAction string
+
+const (
+	// BombPlant is planting the bomb
+	BombPlant BombAction = "plant"
+	// BombDefuse is defusing the bomb
+	BombDefuse BombAction = "defuse"
+)
+
+// BombAbort describes a plant or defuse which was started but not completed
+type BombAbort struct {
+	Action BombAction
+	Player Player
+	// Site is the bombsite of an aborted plant if logged
+	Site string
+	// Begin is the time the action started
+	Begin time.Time
+	// End is the time of the message the abort was detected with
+	End time.Time
+}
+
+// BombTracker follows the bomb events of a stream of messages and
+// detects plants and defuses which were aborted, e.g. because the player
+// stopped, dropped the bomb, got killed or the round ended.
+//
+// A BombTracker is not safe for concurrent use, use a separate
+// BombTracker for each server.
+type BombTracker struct {
+	plant   *PlayerBombBeginPlant
+	defuses []PlayerBombBeginDefuse
+}
+
+// Track adds a message to the tracker and returns the
+// plants and defuses aborted by the message
+func (t *BombTracker) Track(m Message) []BombAbort {
+	var aborts []BombAbort
+
+	switch m := m.(type) {
+	case PlayerBombBeginPlant:
+		// starting again after stopping
+		aborts = t.abortPlant(aborts, m.Time)
+		t.plant = &m
+	case PlayerBombPlanted:
+		t.plant = nil
+	case PlayerBombDropped:
+		aborts = t.abortPlant(aborts, m.Time)
+	case PlayerBombBeginDefuse:
+		aborts = t.abortDefuses(aborts, m.Time, func(d PlayerBombBeginDefuse) bool {
+			return d.Player.ID == m.Player.ID
+		})
+		t.defuses = append(t.defuses, m)
+	case PlayerBombDefused:
+		// other players defusing at the same time didn't complete
+		aborts = t.abortDefuses(aborts, m.Time, func(d PlayerBombBeginDefuse) bool {
+			return d.Player.ID != m.Player.ID
+		})
+		t.defuses = nil
+	case PlayerKill:
+		if t.plant != nil && t.plant.Player.ID == m.Victim.ID {
+			aborts = t.abortPlant(aborts, m.Time)
+		}
+		aborts = t.abortDefuses(aborts, m.Time, func(d PlayerBombBeginDefuse) bool {
+			return d.Player.ID == m.Victim.ID
+		})
+	case TeamNotice, WorldNotice, WorldRoundEnd, WorldRoundStart, FreezTimeStart:
+		aborts = t.abortPlant(aborts, m.GetTime())
+		aborts = t.abortDefuses(aborts, m.GetTime(), func(PlayerBombBeginDefuse) bool {
+			return true
+		})
+	}
+
+	return aborts
+}
+
+// abortPlant adds the pending plant to aborts
+func (t *BombTracker) abortPlant(aborts []BombAbort, end time.Time) []BombAbort {
+	if t.plant == nil {
+		return aborts
+	}
+
+	aborts = append(aborts, BombAbort{
+		Action: BombPlant,
+		Player: t.plant.Player,
+		Site:   t.plant.Site,
+		Begin:  t.plant.Time,
+		End:    end,
+	})
+	t.plant = nil
+
+	return aborts
+}
+
+// abortDefuses adds the pending defuses matching fn to aborts
+func (t *BombTracker) abortDefuses(aborts []BombAbort, end time.Time, fn func(PlayerBombBeginDefuse) bool) []BombAbort {
+	pending := t.defuses[:0]
+
+	for _, d := range t.defuses {
+		if !fn(d) {
+			pending = append(pending, d)
+			continue
+		}
+
+		aborts = append(aborts, BombAbort{
+			Action: BombDefuse,
+			Player: d.Player,
+			Begin:  d.Time,
+			End:    end,
+		})
+	}
+
+	t.defuses = pending
+
+	return aborts
+}
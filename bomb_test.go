@@ -0,0 +1,149 @@
+package csgolog
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBombTracker(t *testing.T) {
+
+	track := func(tr *BombTracker, lines ...string) []BombAbort {
+		var aborts []BombAbort
+		for _, l := range lines {
+			m, err := Parse(line(l))
+			assert(t, nil, err)
+			aborts = append(aborts, tr.Track(m)...)
+		}
+		return aborts
+	}
+
+	t.Run("plant completed", func(t *testing.T) {
+
+		// given
+		tr := &BombTracker{}
+
+		// when
+		aborts := track(tr,
+			`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Bomb_Begin_Plant" at bombsite A`,
+			`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Planted_The_Bomb" at bombsite A`,
+			`Team "TERRORIST" triggered "SFUI_Notice_Target_Bombed" (CT "0") (T "1")`,
+		)
+
+		// then
+		assert(t, 0, len(aborts))
+	})
+
+	t.Run("plant aborted", func(t *testing.T) {
+
+		// given
+		tr := &BombTracker{}
+
+		// when
+		aborts := track(tr,
+			`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Bomb_Begin_Plant" at bombsite A`,
+			`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Bomb_Begin_Plant" at bombsite A`,
+			`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Dropped_The_Bomb"`,
+		)
+
+		// then
+		assert(t, 2, len(aborts))
+		assert(t, BombPlant, aborts[0].Action)
+		assert(t, "Player-Name", aborts[0].Player.Name)
+		assert(t, "A", aborts[0].Site)
+		assert(t, time.Date(2018, time.November, 5, 15, 44, 36, 0, time.UTC), aborts[0].Begin)
+	})
+
+	t.Run("planter killed", func(t *testing.T) {
+
+		// given
+		tr := &BombTracker{}
+
+		// when
+		aborts := track(tr,
+			`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Bomb_Begin_Plant" at bombsite B`,
+			`"Zim<20><BOT><CT>" [-476 -1709 -110] killed "Player-Name<2><STEAM_1:1:0101011><TERRORIST>" [-225 -1829 -168] with "m4a1"`,
+		)
+
+		// then
+		assert(t, 1, len(aborts))
+		assert(t, "B", aborts[0].Site)
+	})
+
+	t.Run("defuse aborted", func(t *testing.T) {
+
+		// given
+		tr := &BombTracker{}
+
+		// when
+		aborts := track(tr,
+			`"Zim<20><BOT><CT>" triggered "Begin_Bomb_Defuse_Without_Kit"`,
+			`"Player-Name<3><STEAM_1:1:0101011><CT>" triggered "Begin_Bomb_Defuse_With_Kit"`,
+			`"Player-Name<3><STEAM_1:1:0101011><CT>" triggered "Defused_The_Bomb"`,
+		)
+
+		// then
+		assert(t, 1, len(aborts))
+		assert(t, BombDefuse, aborts[0].Action)
+		assert(t, "Zim", aborts[0].Player.Name)
+	})
+
+	t.Run("defuser killed", func(t *testing.T) {
+
+		// given
+		tr := &BombTracker{}
+
+		// when
+		aborts := track(tr,
+			`"Zim<20><BOT><CT>" triggered "Begin_Bomb_Defuse_Without_Kit"`,
+			`"Player-Name<3><STEAM_1:1:0101011><CT>" triggered "Begin_Bomb_Defuse_With_Kit"`,
+			`"Player-Name<2><STEAM_1:1:0101012><TERRORIST>" [-225 -1829 -168] killed "Zim<20><BOT><CT>" [-476 -1709 -110] with "glock"`,
+		)
+
+		// then
+		assert(t, 1, len(aborts))
+		assert(t, "Zim", aborts[0].Player.Name)
+
+		// when
+		aborts = track(tr, `Team "TERRORIST" triggered "SFUI_Notice_Target_Bombed" (CT "0") (T "1")`)
+
+		// then
+		assert(t, 1, len(aborts))
+		assert(t, "Player-Name", aborts[0].Player.Name)
+	})
+
+	t.Run("world notice", func(t *testing.T) {
+
+		// given
+		tr := &BombTracker{}
+
+		// when
+		aborts := track(tr,
+			`"Zim<20><BOT><CT>" triggered "Begin_Bomb_Defuse_Without_Kit"`,
+			`World triggered "SFUI_Notice_Target_Bombed"`,
+		)
+
+		// then
+		assert(t, 1, len(aborts))
+		assert(t, BombDefuse, aborts[0].Action)
+	})
+
+	t.Run("example log", func(t *testing.T) {
+
+		// given
+		tr := &BombTracker{}
+		actions := map[BombAction]int{}
+
+		for _, l := range exampleLines(t) {
+			m, _ := Parse(l)
+
+			// when
+			for _, a := range tr.Track(m) {
+				actions[a.Action]++
+			}
+		}
+
+		// then
+		assert(t, 0, actions[BombPlant])
+		assert(t, 2, actions[BombDefuse])
+	})
+}
@@ -1,5 +1,4 @@
 /*
-
 Package csgolog provides utilities for parsing a csgo server logfile.
 It exports types for csgo logfiles, their regular expressions, a function
 for parsing and a function for converting to non-html-escaped JSON.
@@ -50,14 +49,58 @@ const (
 	Get5TeamUnready         Get5Events = "team_unready"
 )
 
+// values of TeamNotice.Notice, the notice is the reason why a round ended
+const (
+	NoticeTargetBombed        = "SFUI_Notice_Target_Bombed"
+	NoticeBombDefused         = "SFUI_Notice_Bomb_Defused"
+	NoticeTargetSaved         = "SFUI_Notice_Target_Saved"
+	NoticeCTsWin              = "SFUI_Notice_CTs_Win"
+	NoticeTerroristsWin       = "SFUI_Notice_Terrorists_Win"
+	NoticeRoundDraw           = "SFUI_Notice_Round_Draw"
+	NoticeHostagesRescued     = "SFUI_Notice_Hostages_Rescued"
+	NoticeAllHostagesRescued  = "SFUI_Notice_All_Hostages_Rescued"
+	NoticeHostagesNotRescued  = "SFUI_Notice_Hostages_Not_Rescued"
+	NoticeTerroristsSurrender = "SFUI_Notice_Terrorists_Surrender"
+	NoticeCTsSurrender        = "SFUI_Notice_CTs_Surrender"
+)
+
+// PauseKind is the kind of a match pause
+type PauseKind string
+
+const (
+	// PauseTactical is a timeout called by a team
+	PauseTactical PauseKind = "tactical"
+	// PauseTechnical is a timeout called by a team because of technical issues
+	PauseTechnical PauseKind = "technical"
+	// PauseAdmin is a pause by mp_pause_match, e.g. by an admin or plugin
+	PauseAdmin PauseKind = "admin"
+	// PauseUnknown is a pause for a reason not known to this package
+	PauseUnknown PauseKind = "unknown"
+)
+
+// values of GrenadeDetonated.Event
+const (
+	// GrenadeEventDetonated is logged when a smoke, flash, HE or molotov detonated
+	GrenadeEventDetonated = "detonated"
+	// GrenadeEventExtinguished is logged when a molotov or incendiary was extinguished
+	GrenadeEventExtinguished = "extinguished"
+	// GrenadeEventStarted is logged when a decoy started firing
+	GrenadeEventStarted = "started"
+	// GrenadeEventStopped is logged when a decoy stopped firing
+	GrenadeEventStopped = "stopped"
+)
+
 // ErrorNoMatch error when pattern is not matching
 var ErrorNoMatch = errors.New("no match")
 
-// LogLinePattern is the regular expression to capture a line of a logfile
-var LogLinePattern = regexp.MustCompile(`L (\d{2}\/\d{2}\/\d{4} - \d{2}:\d{2}:\d{2}): (.*)`)
+// LogLinePattern is the regular expression to capture a line of a logfile,
+// it is anchored so a log prefix within the message body, e.g. in chat,
+// is never taken for the framing of the line
+var LogLinePattern = regexp.MustCompile(`^L (\d{2}\/\d{2}\/\d{4} - \d{2}:\d{2}:\d{2}): (.*)`)
 
-// HTTPLinePattern is the regular expression to capture a line of a HTTP logging
-var HTTPLinePattern = regexp.MustCompile(`(\d{2}\/\d{2}\/\d{4} - \d{2}:\d{2}:\d{2}.\d{3}) - (.*)`)
+// HTTPLinePattern is the regular expression to capture a line of a HTTP logging,
+// it is anchored like LogLinePattern
+var HTTPLinePattern = regexp.MustCompile(`^(\d{2}\/\d{2}\/\d{4} - \d{2}:\d{2}:\d{2}\.\d{3}) - (.*)`)
 
 type (
 
@@ -67,6 +110,9 @@ type (
 		ID      int    `json:"id"`
 		SteamID string `json:"steam_id"`
 		Side    string `json:"side"`
+		// Location is the callout of the position of the player,
+		// only logged by newer servers
+		Location string `json:"location,omitempty"`
 	}
 
 	// Position holds the coords for a event happend on the map
@@ -106,8 +152,8 @@ type (
 
 	// Meta holds time and type of a log message
 	Meta struct {
-		Time time.Time `json:"time"`
-		Type string    `json:"type"`
+		Time time.Time   `json:"time"`
+		Type MessageType `json:"type"`
 	}
 
 	// ServerMessage is received on a server event
@@ -150,7 +196,8 @@ type (
 	}
 
 	// TeamNotice message is received at the end of a round and holds
-	// information about which team won the round and the score
+	// information about which team won the round and the score,
+	// see the Notice constants for known notices
 	TeamNotice struct {
 		Meta
 		Side    string `json:"side"`
@@ -222,6 +269,13 @@ type (
 		Weapon           string   `json:"weapon"`
 		Headshot         bool     `json:"headshot"`
 		Penetrated       bool     `json:"penetrated"`
+		NoScope          bool     `json:"noscope"`
+		ThroughSmoke     bool     `json:"throughsmoke"`
+		AttackerBlind    bool     `json:"attackerblind"`
+		AttackerInAir    bool     `json:"attackerinair"`
+		// Modifiers holds all modifiers of the kill as logged,
+		// including modifiers without a field
+		Modifiers []string `json:"modifiers,omitempty"`
 	}
 
 	// PlayerKillAssist is received when a player assisted killing another
@@ -290,10 +344,12 @@ type (
 		Player Player `json:"player"`
 	}
 
-	// PlayerBombPlanted is received when a player plants the bomb
+	// PlayerBombPlanted is received when a player plants the bomb,
+	// the site is only logged by newer servers
 	PlayerBombPlanted struct {
 		Meta
 		Player Player `json:"player"`
+		Site   string `json:"site,omitempty"`
 	}
 
 	// PlayerBombDropped is received when a player drops the bomb
@@ -405,6 +461,244 @@ type (
 		Weapon           string   `json:"weapon"`
 	}
 
+	// VoteStarted is received when a player calls a vote, e.g. for a
+	// tactical timeout or to surrender
+	VoteStarted struct {
+		Meta
+		Player  Player `json:"player"`
+		Issue   string `json:"issue"`
+		Details string `json:"details"`
+		From    int    `json:"from"`
+	}
+
+	// VoteCast is received when a player votes, Option is the
+	// index of the chosen option, 0 is yes and 1 is no
+	VoteCast struct {
+		Meta
+		Player  Player `json:"player"`
+		Issue   string `json:"issue"`
+		Details string `json:"details"`
+		From    int    `json:"from"`
+		Option  int    `json:"option"`
+	}
+
+	// VoteResult is received when a vote succeeded or failed
+	VoteResult struct {
+		Meta
+		Issue   string `json:"issue"`
+		Details string `json:"details"`
+		Passed  bool   `json:"passed"`
+	}
+
+	// MatchPauseEnabled is received when the match is paused, Reason
+	// is the reason as logged, e.g. TimeOutTs or mp_pause_match
+	MatchPauseEnabled struct {
+		Meta
+		Reason string    `json:"reason"`
+		Kind   PauseKind `json:"kind"`
+		// Side is the team which called a timeout
+		Side string `json:"side,omitempty"`
+	}
+
+	// MatchPauseDisabled is received when the match continues after a pause
+	MatchPauseDisabled struct {
+		Meta
+		Reason string    `json:"reason"`
+		Kind   PauseKind `json:"kind"`
+		// Side is the team which called a timeout
+		Side string `json:"side,omitempty"`
+	}
+
+	// RoundStats is assembled from the multi-line JSON block logged at the
+	// end of a round if the server logs round stats, it holds the stats of
+	// all players for the match so far
+	RoundStats struct {
+		Meta
+		Round   int    `json:"round"`
+		ScoreT  int    `json:"score_t"`
+		ScoreCT int    `json:"score_ct"`
+		Map     string `json:"map"`
+		Server  string `json:"server"`
+		// Fields holds the names of the stats in the order logged
+		Fields  []string           `json:"fields"`
+		Players []PlayerRoundStats `json:"players"`
+	}
+
+	// PlayerRoundStats holds the stats of a player, stats not
+	// known to this package are only available in Stats
+	PlayerRoundStats struct {
+		AccountID       int     `json:"account_id"`
+		Side            string  `json:"side"`
+		Money           int     `json:"money"`
+		Kills           int     `json:"kills"`
+		Deaths          int     `json:"deaths"`
+		Assists         int     `json:"assists"`
+		Damage          int     `json:"damage"`
+		HeadshotPercent float32 `json:"headshot_percent"`
+		KDR             float32 `json:"kdr"`
+		ADR             float32 `json:"adr"`
+		MVP             int     `json:"mvp"`
+		EnemiesFlashed  int     `json:"enemies_flashed"`
+		UtilityDamage   int     `json:"utility_damage"`
+		Kills3          int     `json:"3k"`
+		Kills4          int     `json:"4k"`
+		Kills5          int     `json:"5k"`
+		ClutchKills     int     `json:"clutch_kills"`
+		FirstKills      int     `json:"first_kills"`
+		PistolKills     int     `json:"pistol_kills"`
+		SniperKills     int     `json:"sniper_kills"`
+		BlindKills      int     `json:"blind_kills"`
+		BombKills       int     `json:"bomb_kills"`
+		FireDamage      int     `json:"fire_damage"`
+		UniqueKills     int     `json:"unique_kills"`
+		Dinks           int     `json:"dinks"`
+		ChickenKills    int     `json:"chicken_kills"`
+		// Stats holds all stats by field name as logged
+		Stats map[string]string `json:"stats"`
+	}
+
+	// WarmupStart is received when the warmup starts
+	WarmupStart struct{ Meta }
+
+	// WarmupEnd is received when the warmup ends
+	WarmupEnd struct{ Meta }
+
+	// LoadingMap is received when the server starts loading a map
+	LoadingMap struct {
+		Meta
+		Map string `json:"map"`
+	}
+
+	// StartedMap is received when the server started a map
+	StartedMap struct {
+		Meta
+		Map string `json:"map"`
+		CRC string `json:"crc"`
+	}
+
+	// LogFileStarted is the first line of a logfile
+	LogFileStarted struct {
+		Meta
+		File    string `json:"file"`
+		Game    string `json:"game"`
+		Version string `json:"version"`
+	}
+
+	// LogFileClosed is the last line of a logfile
+	LogFileClosed struct{ Meta }
+
+	// PlayerHostageTouched is received when a player starts
+	// leading a hostage
+	PlayerHostageTouched struct {
+		Meta
+		Player Player `json:"player"`
+	}
+
+	// PlayerHostageRescued is received when a player rescued a hostage
+	PlayerHostageRescued struct {
+		Meta
+		Player Player `json:"player"`
+	}
+
+	// PlayerHostageKilled is received when a player killed a hostage
+	PlayerHostageKilled struct {
+		Meta
+		Player Player `json:"player"`
+	}
+
+	// PlayerNameChanged is received when a player changes the name,
+	// Player holds the old name
+	PlayerNameChanged struct {
+		Meta
+		Player  Player `json:"player"`
+		NewName string `json:"new_name"`
+	}
+
+	// PlayerValidated is received when the Steam ID of a player
+	// was validated. The player hasn't spawned yet, so unlike other
+	// messages the Location of the player is never set.
+	PlayerValidated struct {
+		Meta
+		Player Player `json:"player"`
+	}
+
+	// PlayerKicked is received when a player was kicked from the server
+	PlayerKicked struct {
+		Meta
+		Player  Player `json:"player"`
+		By      string `json:"by"`
+		Message string `json:"message"`
+	}
+
+	// TeamPlaying holds the name of the team playing on a side, it is
+	// received when the match starts and with each match status
+	TeamPlaying struct {
+		Meta
+		Side        string `json:"side"`
+		Team        string `json:"team"`
+		MatchStatus bool   `json:"match_status"`
+	}
+
+	// MatchStatusScore holds the score of the match, it is
+	// received at the end of each round
+	MatchStatusScore struct {
+		Meta
+		ScoreCT      int    `json:"score_ct"`
+		ScoreT       int    `json:"score_t"`
+		Map          string `json:"map"`
+		RoundsPlayed int    `json:"rounds_played"`
+	}
+
+	// Accolade is received at the end of the match for each
+	// award, Player only holds name and id of the player
+	Accolade struct {
+		Meta
+		Kind     string  `json:"kind"`
+		Name     string  `json:"name"`
+		Player   Player  `json:"player"`
+		Value    float32 `json:"value"`
+		Position int     `json:"position"`
+		Score    float32 `json:"score"`
+	}
+
+	// PlayerBombBeginPlant is received when a player starts planting
+	// the bomb, the site is only logged by newer servers
+	PlayerBombBeginPlant struct {
+		Meta
+		Player Player `json:"player"`
+		Site   string `json:"site,omitempty"`
+	}
+
+	// GrenadeDetonated is received with extended logging when a grenade
+	// detonated, a molotov was extinguished or a decoy started or stopped
+	GrenadeDetonated struct {
+		Meta
+		Thrower  Player   `json:"thrower"`
+		Kind     string   `json:"kind"`
+		Entindex int      `json:"entindex"`
+		Event    string   `json:"event"`
+		Position Position `json:"pos"`
+	}
+
+	// PlayerLeftBuyzone is received when a player left the buyzone, it
+	// holds the loadout of the player at that time
+	PlayerLeftBuyzone struct {
+		Meta
+		Player Player `json:"player"`
+		// Items are the weapons, grenades and other items, e.g. weapon_m4a1
+		Items   []string `json:"items"`
+		Armor   int      `json:"armor"`
+		Helmet  bool     `json:"helmet"`
+		Defuser bool     `json:"defuser"`
+	}
+
+	// WorldNotice is received when a round ended with a notice
+	// triggered by the world instead of a team
+	WorldNotice struct {
+		Meta
+		Notice string `json:"notice"`
+	}
+
 	// Unknown holds the raw log message of a message
 	// that is not defined in patterns but starts with time
 	Unknown struct {
@@ -415,7 +709,7 @@ type (
 
 // GetType is the getter fo Meta.Type
 func (m Meta) GetType() string {
-	return m.Type
+	return string(m.Type)
 }
 
 // GetTime is the getter for Meta.Time
@@ -425,6 +719,18 @@ func (m Meta) GetTime() time.Time {
 
 type MessageFunc func(ti time.Time, r []string) Message
 
+// fragments of patterns matching a player tag like
+// Player-Name<12><STEAM_1:1:0101011><CT><BombsiteA>,
+// the location is only logged by newer servers. Besides Steam IDs
+// in any format the tag holds BOT for bots and Console for the
+// server console, whose side is Console as well.
+const (
+	playerIDPattern = `(.+)<(\d+)><([^<>]*)>`
+	sidePattern     = `<(TERRORIST|CT|Unassigned|Spectator|Console|)>`
+	locationPattern = `(?:<([^<>]*)>)?`
+	playerPattern   = playerIDPattern + sidePattern + locationPattern
+)
+
 const (
 	// ServerMessagePattern regular expression
 	ServerMessagePattern = `server_message: "(\w+)"`
@@ -445,49 +751,49 @@ const (
 	// TeamNoticePattern regular expression
 	TeamNoticePattern = `Team "(CT|TERRORIST)" triggered "(\w+)" \(CT "(\d+)"\) \(T "(\d+)"\)`
 	// PlayerConnectedPattern regular expression
-	PlayerConnectedPattern = `"(.+)<(\d+)><([\w:]+)><>" connected, address "(.*)"`
+	PlayerConnectedPattern = `"` + playerIDPattern + `<>` + locationPattern + `" connected, address "(.*)"`
 	// PlayerDisconnectedPattern regular expression
-	PlayerDisconnectedPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT|Unassigned|)>" disconnected \(reason "(.+)"\)`
+	PlayerDisconnectedPattern = `"` + playerPattern + `" disconnected \(reason "(.+)"\)`
 	// PlayerEnteredPattern regular expression
-	PlayerEnteredPattern = `"(.+)<(\d+)><([\w:]+)><>" entered the game`
+	PlayerEnteredPattern = `"` + playerIDPattern + `<>` + locationPattern + `" entered the game`
 	// PlayerBannedPattern regular expression
-	PlayerBannedPattern = `Banid: "(.+)<(\d+)><([\w:]+)><\w*>" was banned "([\w. ]+)" by "(\w+)"`
+	PlayerBannedPattern = `Banid: "` + playerIDPattern + `<\w*>` + locationPattern + `" was banned "([\w. ]+)" by "(\w+)"`
 	// PlayerSwitchedPattern regular expression
-	PlayerSwitchedPattern = `"(.+)<(\d+)><([\w:]+)>" switched from team <(Unassigned|Spectator|TERRORIST|CT)> to <(Unassigned|Spectator|TERRORIST|CT)>`
+	PlayerSwitchedPattern = `"` + playerIDPattern + locationPattern + `" switched from team <(Unassigned|Spectator|TERRORIST|CT)> to <(Unassigned|Spectator|TERRORIST|CT)>`
 	// PlayerSayPattern regular expression
-	PlayerSayPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" say(_team)? "(.*)"`
+	PlayerSayPattern = `"` + playerPattern + `" say(_team)? "(.*)"`
 	// PlayerPurchasePattern regular expression
-	PlayerPurchasePattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" purchased "(\w+)"`
+	PlayerPurchasePattern = `"` + playerPattern + `" purchased "(\w+)"`
 	// PlayerKillPattern regular expression
-	PlayerKillPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" \[(-?\d+) (-?\d+) (-?\d+)\] killed "(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" \[(-?\d+) (-?\d+) (-?\d+)\] with "(\w+)" ?(\(?(headshot|penetrated|headshot penetrated)?\))?`
+	PlayerKillPattern = `"` + playerPattern + `" \[(-?\d+) (-?\d+) (-?\d+)\] killed "` + playerPattern + `" \[(-?\d+) (-?\d+) (-?\d+)\] with "(\w+)"(?: \(([\w ]+)\))?`
 	// PlayerKillAssistPattern regular expression
-	PlayerKillAssistPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" assisted killing "(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>"`
+	PlayerKillAssistPattern = `"` + playerPattern + `" assisted killing "` + playerPattern + `"`
 	// PlayerAttackPattern regular expression
-	PlayerAttackPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" \[(-?\d+) (-?\d+) (-?\d+)\] attacked "(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" \[(-?\d+) (-?\d+) (-?\d+)\] with "(\w+)" \(damage "(\d+)"\) \(damage_armor "(\d+)"\) \(health "(\d+)"\) \(armor "(\d+)"\) \(hitgroup "([\w ]+)"\)`
+	PlayerAttackPattern = `"` + playerPattern + `" \[(-?\d+) (-?\d+) (-?\d+)\] attacked "` + playerPattern + `" \[(-?\d+) (-?\d+) (-?\d+)\] with "(\w+)" \(damage "(\d+)"\) \(damage_armor "(\d+)"\) \(health "(\d+)"\) \(armor "(\d+)"\) \(hitgroup "([\w ]+)"\)`
 	// PlayerKilledBombPattern regular expression
-	PlayerKilledBombPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" \[(-?\d+) (-?\d+) (-?\d+)\] was killed by the bomb\.`
+	PlayerKilledBombPattern = `"` + playerPattern + `" \[(-?\d+) (-?\d+) (-?\d+)\] was killed by the bomb\.`
 	// PlayerKilledSuicidePattern regular expression
-	PlayerKilledSuicidePattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" \[(-?\d+) (-?\d+) (-?\d+)\] committed suicide with "(.*)"`
+	PlayerKilledSuicidePattern = `"` + playerPattern + `" \[(-?\d+) (-?\d+) (-?\d+)\] committed suicide with "(.*)"`
 	// PlayerPickedUpPattern regular expression
-	PlayerPickedUpPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" picked up "(\w+)"`
+	PlayerPickedUpPattern = `"` + playerPattern + `" picked up "(\w+)"`
 	// PlayerDroppedPattern regular expression
-	PlayerDroppedPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT|Unassigned)>" dropped "(\w+)"`
+	PlayerDroppedPattern = `"` + playerPattern + `" dropped "(\w+)"`
 	// PlayerMoneyChangePattern regular expression
-	PlayerMoneyChangePattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" money change (\d+)\+?(-?\d+) = \$(\d+) \(tracked\)( \(purchase: (\w+)\))?`
+	PlayerMoneyChangePattern = `"` + playerPattern + `" money change (\d+)\+?(-?\d+) = \$(\d+) \(tracked\)( \(purchase: (\w+)\))?`
 	// PlayerBombGotPattern regular expression
-	PlayerBombGotPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" triggered "Got_The_Bomb"`
+	PlayerBombGotPattern = `"` + playerPattern + `" triggered "Got_The_Bomb"`
 	// PlayerBombPlantedPattern regular expression
-	PlayerBombPlantedPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" triggered "Planted_The_Bomb"`
+	PlayerBombPlantedPattern = `"` + playerPattern + `" triggered "Planted_The_Bomb"(?: at bombsite (\w+))?`
 	// PlayerBombDroppedPattern regular expression
-	PlayerBombDroppedPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" triggered "Dropped_The_Bomb"`
+	PlayerBombDroppedPattern = `"` + playerPattern + `" triggered "Dropped_The_Bomb"`
 	// PlayerBombBeginDefusePattern regular expression
-	PlayerBombBeginDefusePattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" triggered "Begin_Bomb_Defuse_With(out)?_Kit"`
+	PlayerBombBeginDefusePattern = `"` + playerPattern + `" triggered "Begin_Bomb_Defuse_With(out)?_Kit"`
 	// PlayerBombDefusedPattern regular expression
-	PlayerBombDefusedPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" triggered "Defused_The_Bomb"`
+	PlayerBombDefusedPattern = `"` + playerPattern + `" triggered "Defused_The_Bomb"`
 	// PlayerThrewPattern regular expression
-	PlayerThrewPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" threw (\w+) \[(-?\d+) (-?\d+) (-?\d+)\]( flashbang entindex (\d+))?\)?`
+	PlayerThrewPattern = `"` + playerPattern + `" threw (\w+) \[(-?\d+) (-?\d+) (-?\d+)\]( flashbang entindex (\d+))?\)?`
 	// PlayerBlindedPattern regular expression
-	PlayerBlindedPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" blinded for ([\d.]+) by "(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" from flashbang entindex (\d+)`
+	PlayerBlindedPattern = `"` + playerPattern + `" blinded for ([\d.]+) by "` + playerPattern + `" from flashbang entindex (\d+)`
 	// ProjectileSpawnedPattern regular expression
 	ProjectileSpawnedPattern = `Molotov projectile spawned at (-?\d+\.\d+) (-?\d+\.\d+) (-?\d+\.\d+), velocity (-?\d+\.\d+) (-?\d+\.\d+) (-?\d+\.\d+)`
 	// GameOverPattern regular expression
@@ -499,92 +805,165 @@ const (
 	// RconEventPattern regular expression
 	RconEventPattern = `rcon from "(.*):(\d+)": command "(.*)"`
 	// PlayerKillOtherPattern regular expression
-	PlayerKillOtherPattern = `"(.+)<(\d+)><([\w:]+)><(TERRORIST|CT)>" \[(-?\d+) (-?\d+) (-?\d+)\] killed other "(.+)<(\d+)>" \[(-?\d+) (-?\d+) (-?\d+)\] with "(\w+)"`
-	// TODO // VoteStartPattern = `Vote started "StartTimeOut " from #2 "416<16><STEAM_1:1:55894410><TERRORIST><Area 4>"`
-	// TODO // VoteCastPattern = `Vote cast "StartTimeOut " from #2 "416<16><STEAM_1:1:55894410><TERRORIST><Area 4>" option0`
-	// TODO // VoteSuccessPattern = `Vote cast "StartTimeOut " from #2 "416<16><STEAM_1:1:55894410><TERRORIST><Area 4>`
-	// TODO // MatchPauseEnablePattern = `Match pause is enabled - TimeOutTs`
-	// TODO // MatchPauseEnablePattern = `Match pause is enabled - mp_pause_match`
-	// TODO // MatchPauseDisablePattern = `Match pause is disabled - TimeOutTs`
-	// TODO // MatchPauseDisablePattern = `Match pause is disabled - mp_unpause_match`
+	PlayerKillOtherPattern = `"` + playerPattern + `" \[(-?\d+) (-?\d+) (-?\d+)\] killed other "(.+)<(\d+)>" \[(-?\d+) (-?\d+) (-?\d+)\] with "(\w+)"`
+	// VoteStartedPattern regular expression
+	VoteStartedPattern = `Vote started "(\w+) ?(.*)" from #(\d+) "` + playerPattern + `"`
+	// VoteCastPattern regular expression
+	VoteCastPattern = `Vote cast "(\w+) ?(.*)" from #(\d+) "` + playerPattern + `" option(\d+)`
+	// VoteResultPattern regular expression
+	VoteResultPattern = `Vote (succeeded|failed) "(\w+) ?(.*)"`
+	// MatchPauseEnabledPattern regular expression
+	MatchPauseEnabledPattern = `Match pause is enabled - (\w+)`
+	// MatchPauseDisabledPattern regular expression
+	MatchPauseDisabledPattern = `Match pause is disabled - (\w+)`
+	// WarmupStartPattern regular expression
+	WarmupStartPattern = `World triggered "Warmup_Start"`
+	// WarmupEndPattern regular expression
+	WarmupEndPattern = `World triggered "Warmup_End"`
+	// LoadingMapPattern regular expression
+	LoadingMapPattern = `Loading map "(.+)"`
+	// StartedMapPattern regular expression
+	StartedMapPattern = `Started map "(.+)" \(CRC "(-?\d+)"\)`
+	// LogFileStartedPattern regular expression
+	LogFileStartedPattern = `Log file started \(file "(.*)"\) \(game "(.*)"\) \(version "(.*)"\)`
+	// LogFileClosedPattern regular expression
+	LogFileClosedPattern = `Log file closed`
+	// PlayerHostageTouchedPattern regular expression
+	PlayerHostageTouchedPattern = `"` + playerPattern + `" triggered "Touched_A_Hostage"`
+	// PlayerHostageRescuedPattern regular expression
+	PlayerHostageRescuedPattern = `"` + playerPattern + `" triggered "Rescued_A_Hostage"`
+	// PlayerHostageKilledPattern regular expression
+	PlayerHostageKilledPattern = `"` + playerPattern + `" triggered "Killed_A_Hostage"`
+	// PlayerNameChangedPattern regular expression
+	PlayerNameChangedPattern = `"` + playerPattern + `" changed name to "(.*)"`
+	// PlayerValidatedPattern regular expression
+	PlayerValidatedPattern = `"` + playerIDPattern + `(?:<>)?" STEAM USERID validated`
+	// PlayerKickedPattern regular expression
+	PlayerKickedPattern = `"` + playerPattern + `" was kicked by "(.*)" \(message "(.*)"\)`
+	// TeamPlayingPattern regular expression
+	TeamPlayingPattern = `^(MatchStatus: )?Team playing "(CT|TERRORIST)": (.*)`
+	// MatchStatusScorePattern regular expression
+	MatchStatusScorePattern = `MatchStatus: Score: (\d+):(\d+) on map "(.*)" RoundsPlayed: (-?\d+)`
+	// AccoladePattern regular expression
+	AccoladePattern = `ACCOLADE, (\w+): \{(\w+)\},\s+(.+)<(\d+)>,\s+VALUE: (-?\d+\.?\d*),\s+POS: (\d+),\s+SCORE: (-?\d+\.?\d*)`
+	// PlayerBombBeginPlantPattern regular expression
+	PlayerBombBeginPlantPattern = `"` + playerPattern + `" triggered "Bomb_Begin_Plant"(?: at bombsite (\w+))?`
+	// GrenadeDetonatedPattern regular expression
+	GrenadeDetonatedPattern = `"` + playerPattern + `" (\w+) entindex (\d+) (detonated|extinguished|started|stopped) at \[(-?\d+) (-?\d+) (-?\d+)\]`
+	// PlayerLeftBuyzonePattern regular expression
+	PlayerLeftBuyzonePattern = `"` + playerPattern + `" left buyzone with \[ ?([^\]]*)\]`
+	// WorldNoticePattern regular expression
+	WorldNoticePattern = `World triggered "(SFUI_Notice_\w+)"`
 )
 
-var DefaultPatterns = map[*regexp.Regexp]MessageFunc{
-	regexp.MustCompile(ServerMessagePattern):         NewServerMessage,
-	regexp.MustCompile(FreezTimeStartPattern):        NewFreezTimeStart,
-	regexp.MustCompile(WorldMatchStartPattern):       NewWorldMatchStart,
-	regexp.MustCompile(WorldRoundStartPattern):       NewWorldRoundStart,
-	regexp.MustCompile(WorldRoundRestartPattern):     NewWorldRoundRestart,
-	regexp.MustCompile(WorldRoundEndPattern):         NewWorldRoundEnd,
-	regexp.MustCompile(WorldGameCommencingPattern):   NewWorldGameCommencing,
-	regexp.MustCompile(TeamScoredPattern):            NewTeamScored,
-	regexp.MustCompile(TeamNoticePattern):            NewTeamNotice,
-	regexp.MustCompile(PlayerConnectedPattern):       NewPlayerConnected,
-	regexp.MustCompile(PlayerDisconnectedPattern):    NewPlayerDisconnected,
-	regexp.MustCompile(PlayerEnteredPattern):         NewPlayerEntered,
-	regexp.MustCompile(PlayerBannedPattern):          NewPlayerBanned,
-	regexp.MustCompile(PlayerSwitchedPattern):        NewPlayerSwitched,
-	regexp.MustCompile(PlayerSayPattern):             NewPlayerSay,
-	regexp.MustCompile(PlayerPurchasePattern):        NewPlayerPurchase,
-	regexp.MustCompile(PlayerKillPattern):            NewPlayerKill,
-	regexp.MustCompile(PlayerKillAssistPattern):      NewPlayerKillAssist,
-	regexp.MustCompile(PlayerAttackPattern):          NewPlayerAttack,
-	regexp.MustCompile(PlayerKilledBombPattern):      NewPlayerKilledBomb,
-	regexp.MustCompile(PlayerKilledSuicidePattern):   NewPlayerKilledSuicide,
-	regexp.MustCompile(PlayerPickedUpPattern):        NewPlayerPickedUp,
-	regexp.MustCompile(PlayerDroppedPattern):         NewPlayerDropped,
-	regexp.MustCompile(PlayerMoneyChangePattern):     NewPlayerMoneyChange,
-	regexp.MustCompile(PlayerBombGotPattern):         NewPlayerBombGot,
-	regexp.MustCompile(PlayerBombPlantedPattern):     NewPlayerBombPlanted,
-	regexp.MustCompile(PlayerBombDroppedPattern):     NewPlayerBombDropped,
-	regexp.MustCompile(PlayerBombBeginDefusePattern): NewPlayerBombBeginDefuse,
-	regexp.MustCompile(PlayerBombDefusedPattern):     NewPlayerBombDefused,
-	regexp.MustCompile(PlayerThrewPattern):           NewPlayerThrew,
-	regexp.MustCompile(PlayerBlindedPattern):         NewPlayerBlinded,
-	regexp.MustCompile(ProjectileSpawnedPattern):     NewProjectileSpawned,
-	regexp.MustCompile(GameOverPattern):              NewGameOver,
-	regexp.MustCompile(ServerCvarPattern):            NewServerCvar,
-	regexp.MustCompile(Get5EventPattern):             NewGet5Event,
-	regexp.MustCompile(RconEventPattern):             NewRconEvent,
-	regexp.MustCompile(PlayerKillOtherPattern):       NewPlayerKillOther,
-}
+// DefaultPatterns is the registry used by Parse, patterns are tried
+// in the order listed here
+var DefaultPatterns = NewRegistry(
+	NewPattern(TypeServerMessage, ServerMessagePattern, NewServerMessage),
+	NewPattern(TypeFreezTimeStart, FreezTimeStartPattern, NewFreezTimeStart),
+	NewPattern(TypeWorldMatchStart, WorldMatchStartPattern, NewWorldMatchStart),
+	NewPattern(TypeWorldRoundStart, WorldRoundStartPattern, NewWorldRoundStart),
+	NewPattern(TypeWorldRoundRestart, WorldRoundRestartPattern, NewWorldRoundRestart),
+	NewPattern(TypeWorldRoundEnd, WorldRoundEndPattern, NewWorldRoundEnd),
+	NewPattern(TypeWorldGameCommencing, WorldGameCommencingPattern, NewWorldGameCommencing),
+	NewPattern(TypeTeamScored, TeamScoredPattern, NewTeamScored),
+	NewPattern(TypeTeamNotice, TeamNoticePattern, NewTeamNotice),
+	NewPattern(TypePlayerConnected, PlayerConnectedPattern, NewPlayerConnected),
+	NewPattern(TypePlayerDisconnected, PlayerDisconnectedPattern, NewPlayerDisconnected),
+	NewPattern(TypePlayerEntered, PlayerEnteredPattern, NewPlayerEntered),
+	NewPattern(TypePlayerBanned, PlayerBannedPattern, NewPlayerBanned),
+	NewPattern(TypePlayerSwitched, PlayerSwitchedPattern, NewPlayerSwitched),
+	NewPattern(TypePlayerSay, PlayerSayPattern, NewPlayerSay),
+	NewPattern(TypePlayerPurchase, PlayerPurchasePattern, NewPlayerPurchase),
+	NewPattern(TypePlayerKill, PlayerKillPattern, NewPlayerKill),
+	NewPattern(TypePlayerKillAssist, PlayerKillAssistPattern, NewPlayerKillAssist),
+	NewPattern(TypePlayerAttack, PlayerAttackPattern, NewPlayerAttack),
+	NewPattern(TypePlayerKilledBomb, PlayerKilledBombPattern, NewPlayerKilledBomb),
+	NewPattern(TypePlayerKilledSuicide, PlayerKilledSuicidePattern, NewPlayerKilledSuicide),
+	NewPattern(TypePlayerPickedUp, PlayerPickedUpPattern, NewPlayerPickedUp),
+	NewPattern(TypePlayerDropped, PlayerDroppedPattern, NewPlayerDropped),
+	NewPattern(TypePlayerMoneyChange, PlayerMoneyChangePattern, NewPlayerMoneyChange),
+	NewPattern(TypePlayerBombGot, PlayerBombGotPattern, NewPlayerBombGot),
+	NewPattern(TypePlayerBombPlanted, PlayerBombPlantedPattern, NewPlayerBombPlanted),
+	NewPattern(TypePlayerBombDropped, PlayerBombDroppedPattern, NewPlayerBombDropped),
+	NewPattern(TypePlayerBombBeginDefuse, PlayerBombBeginDefusePattern, NewPlayerBombBeginDefuse),
+	NewPattern(TypePlayerBombDefused, PlayerBombDefusedPattern, NewPlayerBombDefused),
+	NewPattern(TypePlayerThrew, PlayerThrewPattern, NewPlayerThrew),
+	NewPattern(TypePlayerBlinded, PlayerBlindedPattern, NewPlayerBlinded),
+	NewPattern(TypeProjectileSpawned, ProjectileSpawnedPattern, NewProjectileSpawned),
+	NewPattern(TypeGameOver, GameOverPattern, NewGameOver),
+	NewPattern(TypeServerCvar, ServerCvarPattern, NewServerCvar),
+	NewPattern(TypeGet5Event, Get5EventPattern, NewGet5Event),
+	NewPattern(TypeRcon, RconEventPattern, NewRconEvent),
+	NewPattern(TypePlayerKillOther, PlayerKillOtherPattern, NewPlayerKillOther),
+	NewPattern(TypeVoteStarted, VoteStartedPattern, NewVoteStarted),
+	NewPattern(TypeVoteCast, VoteCastPattern, NewVoteCast),
+	NewPattern(TypeVoteResult, VoteResultPattern, NewVoteResult),
+	NewPattern(TypeMatchPauseEnabled, MatchPauseEnabledPattern, NewMatchPauseEnabled),
+	NewPattern(TypeMatchPauseDisabled, MatchPauseDisabledPattern, NewMatchPauseDisabled),
+	NewPattern(TypeWarmupStart, WarmupStartPattern, NewWarmupStart),
+	NewPattern(TypeWarmupEnd, WarmupEndPattern, NewWarmupEnd),
+	NewPattern(TypeLoadingMap, LoadingMapPattern, NewLoadingMap),
+	NewPattern(TypeStartedMap, StartedMapPattern, NewStartedMap),
+	NewPattern(TypeLogFileStarted, LogFileStartedPattern, NewLogFileStarted),
+	NewPattern(TypeLogFileClosed, LogFileClosedPattern, NewLogFileClosed),
+	NewPattern(TypePlayerHostageTouched, PlayerHostageTouchedPattern, NewPlayerHostageTouched),
+	NewPattern(TypePlayerHostageRescued, PlayerHostageRescuedPattern, NewPlayerHostageRescued),
+	NewPattern(TypePlayerHostageKilled, PlayerHostageKilledPattern, NewPlayerHostageKilled),
+	NewPattern(TypePlayerNameChanged, PlayerNameChangedPattern, NewPlayerNameChanged),
+	NewPattern(TypePlayerValidated, PlayerValidatedPattern, NewPlayerValidated),
+	NewPattern(TypePlayerKicked, PlayerKickedPattern, NewPlayerKicked),
+	NewPattern(TypeTeamPlaying, TeamPlayingPattern, NewTeamPlaying),
+	NewPattern(TypeMatchStatusScore, MatchStatusScorePattern, NewMatchStatusScore),
+	NewPattern(TypeAccolade, AccoladePattern, NewAccolade),
+	NewPattern(TypePlayerBombBeginPlant, PlayerBombBeginPlantPattern, NewPlayerBombBeginPlant),
+	NewPattern(TypeGrenadeDetonated, GrenadeDetonatedPattern, NewGrenadeDetonated),
+	checked(NewPattern(TypePlayerLeftBuyzone, PlayerLeftBuyzonePattern, NewPlayerLeftBuyzone), checkPlayerLeftBuyzone),
+	NewPattern(TypeWorldNotice, WorldNoticePattern, NewWorldNotice),
+)
 
 // Parse parses a plain log message and returns
-// message type or error if there's no match
+// message type or error if there's no match.
+// Lines of a logfile as well as lines received by HTTP logging are accepted.
 func Parse(line string) (Message, error) {
-	return ParseWithPatterns(line, DefaultPatterns)
+	return ParseWith(line, DefaultPatterns)
 }
 
-// Parse attempts to match a plain log message against the map of provided patterns,
-// if the line matches a key from the map, the corresponding MessageFunc is called on the line to
-// parse it into a Message
-func ParseWithPatterns(line string, patterns map[*regexp.Regexp]MessageFunc) (Message, error) {
-	// pattern for date, beginning of a log message
-	result := LogLinePattern.FindStringSubmatch(line)
-
-	// if result set is empty, parsing failed, return error
-	if result == nil {
-		return nil, ErrorNoMatch
+// ParseWith attempts to match a plain log message against the patterns of
+// the registry in order, the MessageFunc of the first matching pattern
+// is called on the line to parse it into a Message. DefaultPatterns is
+// used if registry is nil.
+func ParseWith(line string, registry *Registry) (Message, error) {
+	if registry == nil {
+		registry = DefaultPatterns
 	}
 
-	// parse time
-	ti, err := time.Parse("01/02/2006 - 15:04:05", result[1])
+	p := Parser{registry: registry}
+	return p.Parse(line)
+}
+
+// ParseWithPatterns attempts to match a plain log message against the map of provided patterns,
+// if the line matches a key from the map, the corresponding MessageFunc is called on the line to
+// parse it into a Message.
+// The order in which the patterns of the map are tried is undefined, use ParseWith
+// with a Registry if patterns may match the same line.
+func ParseWithPatterns(line string, patterns map[*regexp.Regexp]MessageFunc) (Message, error) {
+	f, err := ParseFrame(line)
 
-	// if parsing the date failed, return error
 	if err != nil {
 		return nil, err
 	}
 
 	// check all patterns, return if a pattern matches
 	for re, fun := range patterns {
-		if result := re.FindStringSubmatch(result[2]); result != nil {
-			return fun(ti, result), nil
+		if result := re.FindStringSubmatch(f.Body); result != nil {
+			return fun(f.Time, result), nil
 		}
 	}
 
 	// if there was no match above but format of the log message was correct
 	// it's a valid logline but pattern is not defined, return unknown type
-	return NewUnknown(ti, result[1:]), nil
+	return NewUnknown(f.Time, []string{line, f.Body}), nil
 }
 
 // ToJSON marshals messages to JSON without escaping html
@@ -596,7 +975,7 @@ func ToJSON(m Message) string {
 	return buf.String()
 }
 
-func NewMeta(ti time.Time, ty string) Meta {
+func NewMeta(ti time.Time, ty MessageType) Meta {
 	return Meta{
 		Time: ti,
 		Type: ty,
@@ -605,44 +984,44 @@ func NewMeta(ti time.Time, ty string) Meta {
 
 func NewServerMessage(ti time.Time, r []string) Message {
 	return ServerMessage{
-		Meta: NewMeta(ti, "ServerMessage"),
+		Meta: NewMeta(ti, TypeServerMessage),
 		Text: r[1],
 	}
 }
 
 func NewFreezTimeStart(ti time.Time, r []string) Message {
-	return FreezTimeStart{NewMeta(ti, "FreezTimeStart")}
+	return FreezTimeStart{NewMeta(ti, TypeFreezTimeStart)}
 }
 
 func NewWorldMatchStart(ti time.Time, r []string) Message {
 	return WorldMatchStart{
-		Meta: NewMeta(ti, "WorldMatchStart"),
+		Meta: NewMeta(ti, TypeWorldMatchStart),
 		Map:  r[1],
 	}
 }
 
 func NewWorldRoundStart(ti time.Time, r []string) Message {
-	return WorldRoundStart{NewMeta(ti, "WorldRoundStart")}
+	return WorldRoundStart{NewMeta(ti, TypeWorldRoundStart)}
 }
 
 func NewWorldRoundRestart(ti time.Time, r []string) Message {
 	return WorldRoundRestart{
-		Meta:     NewMeta(ti, "WorldRoundRestart"),
+		Meta:     NewMeta(ti, TypeWorldRoundRestart),
 		Timeleft: toInt(r[1]),
 	}
 }
 
 func NewWorldRoundEnd(ti time.Time, r []string) Message {
-	return WorldRoundEnd{NewMeta(ti, "WorldRoundEnd")}
+	return WorldRoundEnd{NewMeta(ti, TypeWorldRoundEnd)}
 }
 
 func NewWorldGameCommencing(ti time.Time, r []string) Message {
-	return WorldGameCommencing{NewMeta(ti, "WorldGameCommencing")}
+	return WorldGameCommencing{NewMeta(ti, TypeWorldGameCommencing)}
 }
 
 func NewTeamScored(ti time.Time, r []string) Message {
 	return TeamScored{
-		Meta:       NewMeta(ti, "TeamScored"),
+		Meta:       NewMeta(ti, TypeTeamScored),
 		Side:       r[1],
 		Score:      toInt(r[2]),
 		NumPlayers: toInt(r[3]),
@@ -651,7 +1030,7 @@ func NewTeamScored(ti time.Time, r []string) Message {
 
 func NewTeamNotice(ti time.Time, r []string) Message {
 	return TeamNotice{
-		Meta:    NewMeta(ti, "TeamNotice"),
+		Meta:    NewMeta(ti, TypeTeamNotice),
 		Side:    r[1],
 		Notice:  r[2],
 		ScoreCT: toInt(r[3]),
@@ -661,362 +1040,278 @@ func NewTeamNotice(ti time.Time, r []string) Message {
 
 func NewPlayerConnected(ti time.Time, r []string) Message {
 	return PlayerConnected{
-		Meta: NewMeta(ti, "PlayerConnected"),
+		Meta: NewMeta(ti, TypePlayerConnected),
 		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    "",
+			Name:     r[1],
+			ID:       toInt(r[2]),
+			SteamID:  r[3],
+			Side:     "",
+			Location: r[4],
 		},
-		Address: r[4],
+		Address: r[5],
 	}
 }
 
 func NewPlayerDisconnected(ti time.Time, r []string) Message {
 	return PlayerDisconnected{
-		Meta: NewMeta(ti, "PlayerDisconnected"),
-		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
-		Reason: r[5],
+		Meta:   NewMeta(ti, TypePlayerDisconnected),
+		Player: toPlayer(r[1:]),
+		Reason: r[6],
 	}
 }
 
 func NewPlayerEntered(ti time.Time, r []string) Message {
 	return PlayerEntered{
-		Meta: NewMeta(ti, "PlayerEntered"),
+		Meta: NewMeta(ti, TypePlayerEntered),
 		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    "",
+			Name:     r[1],
+			ID:       toInt(r[2]),
+			SteamID:  r[3],
+			Side:     "",
+			Location: r[4],
 		},
 	}
 }
 
 func NewPlayerBanned(ti time.Time, r []string) Message {
 	return PlayerBanned{
-		Meta: NewMeta(ti, "PlayerBanned"),
+		Meta: NewMeta(ti, TypePlayerBanned),
 		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    "",
+			Name:     r[1],
+			ID:       toInt(r[2]),
+			SteamID:  r[3],
+			Side:     "",
+			Location: r[4],
 		},
-		Duration: r[4],
-		By:       r[5],
+		Duration: r[5],
+		By:       r[6],
 	}
 }
 
 func NewPlayerSwitched(ti time.Time, r []string) Message {
 	return PlayerSwitched{
-		Meta: NewMeta(ti, "PlayerSwitched"),
+		Meta: NewMeta(ti, TypePlayerSwitched),
 		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    "",
+			Name:     r[1],
+			ID:       toInt(r[2]),
+			SteamID:  r[3],
+			Side:     "",
+			Location: r[4],
 		},
-		From: r[4],
-		To:   r[5],
+		From: r[5],
+		To:   r[6],
 	}
 }
 
 func NewPlayerSay(ti time.Time, r []string) Message {
 	return PlayerSay{
-		Meta: NewMeta(ti, "PlayerSay"),
-		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
-		Team: r[5] == "_team",
-		Text: r[6],
+		Meta:   NewMeta(ti, TypePlayerSay),
+		Player: toPlayer(r[1:]),
+		Team:   r[6] == "_team",
+		Text:   r[7],
 	}
 }
 
 func NewPlayerPurchase(ti time.Time, r []string) Message {
 	return PlayerPurchase{
-		Meta: NewMeta(ti, "PlayerPurchase"),
-		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
-		Item: r[5],
+		Meta:   NewMeta(ti, TypePlayerPurchase),
+		Player: toPlayer(r[1:]),
+		Item:   r[6],
 	}
 }
 
 func NewPlayerKill(ti time.Time, r []string) Message {
-	return PlayerKill{
-		Meta: NewMeta(ti, "PlayerKill"),
-		Attacker: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
+	pk := PlayerKill{
+		Meta:     NewMeta(ti, TypePlayerKill),
+		Attacker: toPlayer(r[1:]),
 		AttackerPosition: Position{
-			X: toInt(r[5]),
-			Y: toInt(r[6]),
-			Z: toInt(r[7]),
-		},
-		Victim: Player{
-			Name:    r[8],
-			ID:      toInt(r[9]),
-			SteamID: r[10],
-			Side:    r[11],
+			X: toInt(r[6]),
+			Y: toInt(r[7]),
+			Z: toInt(r[8]),
 		},
+		Victim: toPlayer(r[9:]),
 		VictimPosition: Position{
-			X: toInt(r[12]),
-			Y: toInt(r[13]),
-			Z: toInt(r[14]),
+			X: toInt(r[14]),
+			Y: toInt(r[15]),
+			Z: toInt(r[16]),
 		},
-		Weapon:     r[15],
-		Headshot:   strings.Contains(r[17], "headshot"),
-		Penetrated: strings.Contains(r[17], "penetrated"),
+		Weapon: r[17],
+	}
+
+	if r[18] != "" {
+		pk.Modifiers = strings.Fields(r[18])
+	}
+
+	for _, mod := range pk.Modifiers {
+		switch mod {
+		case "headshot":
+			pk.Headshot = true
+		case "penetrated":
+			pk.Penetrated = true
+		case "noscope":
+			pk.NoScope = true
+		case "throughsmoke":
+			pk.ThroughSmoke = true
+		case "attackerblind":
+			pk.AttackerBlind = true
+		case "attackerinair":
+			pk.AttackerInAir = true
+		}
 	}
+
+	return pk
 }
 
 func NewPlayerKillAssist(ti time.Time, r []string) Message {
 	return PlayerKillAssist{
-		Meta: NewMeta(ti, "PlayerKillAssist"),
-		Attacker: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
-		Victim: Player{
-			Name:    r[5],
-			ID:      toInt(r[6]),
-			SteamID: r[7],
-			Side:    r[8],
-		},
+		Meta:     NewMeta(ti, TypePlayerKillAssist),
+		Attacker: toPlayer(r[1:]),
+		Victim:   toPlayer(r[6:]),
 	}
 }
 
 func NewPlayerAttack(ti time.Time, r []string) Message {
 	return PlayerAttack{
-		Meta: NewMeta(ti, "PlayerAttack"),
-		Attacker: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
+		Meta:     NewMeta(ti, TypePlayerAttack),
+		Attacker: toPlayer(r[1:]),
 		AttackerPosition: Position{
-			X: toInt(r[5]),
-			Y: toInt(r[6]),
-			Z: toInt(r[7]),
-		},
-		Victim: Player{
-			Name:    r[8],
-			ID:      toInt(r[9]),
-			SteamID: r[10],
-			Side:    r[11],
+			X: toInt(r[6]),
+			Y: toInt(r[7]),
+			Z: toInt(r[8]),
 		},
+		Victim: toPlayer(r[9:]),
 		VictimPosition: Position{
-			X: toInt(r[12]),
-			Y: toInt(r[13]),
-			Z: toInt(r[14]),
+			X: toInt(r[14]),
+			Y: toInt(r[15]),
+			Z: toInt(r[16]),
 		},
-		Weapon:      r[15],
-		Damage:      toInt(r[16]),
-		DamageArmor: toInt(r[17]),
-		Health:      toInt(r[18]),
-		Armor:       toInt(r[19]),
-		Hitgroup:    r[20],
+		Weapon:      r[17],
+		Damage:      toInt(r[18]),
+		DamageArmor: toInt(r[19]),
+		Health:      toInt(r[20]),
+		Armor:       toInt(r[21]),
+		Hitgroup:    r[22],
 	}
 }
 
 func NewPlayerKilledBomb(ti time.Time, r []string) Message {
 	return PlayerKilledBomb{
-		Meta: NewMeta(ti, "PlayerKilledBomb"),
-		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
+		Meta:   NewMeta(ti, TypePlayerKilledBomb),
+		Player: toPlayer(r[1:]),
 		Position: Position{
-			X: toInt(r[5]),
-			Y: toInt(r[6]),
-			Z: toInt(r[7]),
+			X: toInt(r[6]),
+			Y: toInt(r[7]),
+			Z: toInt(r[8]),
 		},
 	}
 }
 
 func NewPlayerKilledSuicide(ti time.Time, r []string) Message {
 	return PlayerKilledSuicide{
-		Meta: NewMeta(ti, "PlayerKilledSuicide"),
-		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
+		Meta:   NewMeta(ti, TypePlayerKilledSuicide),
+		Player: toPlayer(r[1:]),
 		Position: Position{
-			X: toInt(r[5]),
-			Y: toInt(r[6]),
-			Z: toInt(r[7]),
+			X: toInt(r[6]),
+			Y: toInt(r[7]),
+			Z: toInt(r[8]),
 		},
-		With: r[8],
+		With: r[9],
 	}
 }
 
 func NewPlayerPickedUp(ti time.Time, r []string) Message {
 	return PlayerPickedUp{
-		Meta: NewMeta(ti, "PlayerPickedUp"),
-		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
-		Item: r[5],
+		Meta:   NewMeta(ti, TypePlayerPickedUp),
+		Player: toPlayer(r[1:]),
+		Item:   r[6],
 	}
 }
 
 func NewPlayerDropped(ti time.Time, r []string) Message {
 	return PlayerDropped{
-		Meta: NewMeta(ti, "PlayerDropped"),
-		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
-		Item: r[5],
+		Meta:   NewMeta(ti, TypePlayerDropped),
+		Player: toPlayer(r[1:]),
+		Item:   r[6],
 	}
 }
 
 func NewPlayerMoneyChange(ti time.Time, r []string) Message {
 	return PlayerMoneyChange{
-		Meta: NewMeta(ti, "PlayerMoneyChange"),
-		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
+		Meta:   NewMeta(ti, TypePlayerMoneyChange),
+		Player: toPlayer(r[1:]),
 		Equation: Equation{
-			A:      toInt(r[5]),
-			B:      toInt(r[6]),
-			Result: toInt(r[7]),
+			A:      toInt(r[6]),
+			B:      toInt(r[7]),
+			Result: toInt(r[8]),
 		},
-		Purchase: r[9],
+		Purchase: r[10],
 	}
 }
 
 func NewPlayerBombGot(ti time.Time, r []string) Message {
 	return PlayerBombGot{
-		Meta: NewMeta(ti, "PlayerBombGot"),
-		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
+		Meta:   NewMeta(ti, TypePlayerBombGot),
+		Player: toPlayer(r[1:]),
 	}
 }
 
 func NewPlayerBombPlanted(ti time.Time, r []string) Message {
 	return PlayerBombPlanted{
-		Meta: NewMeta(ti, "PlayerBombPlanted"),
-		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
+		Meta:   NewMeta(ti, TypePlayerBombPlanted),
+		Player: toPlayer(r[1:]),
+		Site:   r[6],
 	}
 }
 
 func NewPlayerBombDropped(ti time.Time, r []string) Message {
 	return PlayerBombDropped{
-		Meta: NewMeta(ti, "PlayerBombDropped"),
-		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
+		Meta:   NewMeta(ti, TypePlayerBombDropped),
+		Player: toPlayer(r[1:]),
 	}
 }
 
 func NewPlayerBombBeginDefuse(ti time.Time, r []string) Message {
 	return PlayerBombBeginDefuse{
-		Meta: NewMeta(ti, "PlayerBombBeginDefuse"),
-		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
-		Kit: !(r[5] == "out"),
+		Meta:   NewMeta(ti, TypePlayerBombBeginDefuse),
+		Player: toPlayer(r[1:]),
+		Kit:    !(r[6] == "out"),
 	}
 }
 
 func NewPlayerBombDefused(ti time.Time, r []string) Message {
 	return PlayerBombDefused{
-		Meta: NewMeta(ti, "PlayerBombDefused"),
-		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
+		Meta:   NewMeta(ti, TypePlayerBombDefused),
+		Player: toPlayer(r[1:]),
 	}
 }
 
 func NewPlayerThrew(ti time.Time, r []string) Message {
 	return PlayerThrew{
-		Meta: NewMeta(ti, "PlayerThrew"),
-		Player: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
-		Grenade: r[5],
+		Meta:    NewMeta(ti, TypePlayerThrew),
+		Player:  toPlayer(r[1:]),
+		Grenade: r[6],
 		Position: Position{
-			X: toInt(r[6]),
-			Y: toInt(r[7]),
-			Z: toInt(r[8]),
+			X: toInt(r[7]),
+			Y: toInt(r[8]),
+			Z: toInt(r[9]),
 		},
-		Entindex: toInt(r[10]),
+		Entindex: toInt(r[11]),
 	}
 }
 
 func NewPlayerBlinded(ti time.Time, r []string) Message {
 	return PlayerBlinded{
-		Meta: NewMeta(ti, "PlayerBlinded"),
-		Victim: Player{
-			Name:    r[1],
-			ID:      toInt(r[2]),
-			SteamID: r[3],
-			Side:    r[4],
-		},
-		For: toFloat32(r[5]),
-		Attacker: Player{
-			Name:    r[6],
-			ID:      toInt(r[7]),
-			SteamID: r[8],
-			Side:    r[9],
-		},
-		Entindex: toInt(r[10]),
+		Meta:     NewMeta(ti, TypePlayerBlinded),
+		Victim:   toPlayer(r[1:]),
+		For:      toFloat32(r[6]),
+		Attacker: toPlayer(r[7:]),
+		Entindex: toInt(r[12]),
 	}
 }
 
 func NewProjectileSpawned(ti time.Time, r []string) Message {
 	return ProjectileSpawned{
-		Meta: NewMeta(ti, "ProjectileSpawned"),
+		Meta: NewMeta(ti, TypeProjectileSpawned),
 		Position: PositionFloat{
 			X: toFloat32(r[1]),
 			Y: toFloat32(r[2]),
@@ -1032,7 +1327,7 @@ func NewProjectileSpawned(ti time.Time, r []string) Message {
 
 func NewGameOver(ti time.Time, r []string) Message {
 	return GameOver{
-		Meta:     NewMeta(ti, "GameOver"),
+		Meta:     NewMeta(ti, TypeGameOver),
 		Mode:     r[1],
 		MapGroup: r[2],
 		Map:      r[3],
@@ -1044,7 +1339,7 @@ func NewGameOver(ti time.Time, r []string) Message {
 
 func NewServerCvar(ti time.Time, r []string) Message {
 	return ServerCvar{
-		Meta:  NewMeta(ti, "ServerCvar"),
+		Meta:  NewMeta(ti, TypeServerCvar),
 		Key:   r[1],
 		Value: r[2],
 	}
@@ -1053,7 +1348,7 @@ func NewServerCvar(ti time.Time, r []string) Message {
 func NewGet5Event(ti time.Time, r []string) Message {
 	// r[1]=ignored, r[2]=matchid, r[3]=params r[4]=event
 	get5event := Get5Event{
-		Meta:    NewMeta(ti, "Get5Event"),
+		Meta:    NewMeta(ti, TypeGet5Event),
 		Matchid: r[2],
 		Params:  Get5EventParams{},
 		Event:   r[4],
@@ -1072,7 +1367,7 @@ func NewRconEvent(ti time.Time, r []string) Message {
 		return NewUnknown(ti, r)
 	}
 	return Rcon{
-		Meta:    NewMeta(ti, "Rcon"),
+		Meta:    NewMeta(ti, TypeRcon),
 		IP:      r[1],
 		Port:    uint(p),
 		Command: r[3],
@@ -1081,38 +1376,457 @@ func NewRconEvent(ti time.Time, r []string) Message {
 
 func NewPlayerKillOther(ti time.Time, r []string) Message {
 	return PlayerKillOther{
-		Meta: NewMeta(ti, "PlayerKillOther"),
-		Attacker: Player{
+		Meta:     NewMeta(ti, TypePlayerKillOther),
+		Attacker: toPlayer(r[1:]),
+		AttackerPosition: Position{
+			X: toInt(r[6]),
+			Y: toInt(r[7]),
+			Z: toInt(r[8]),
+		},
+		Victim:   r[9],
+		VictimID: r[10],
+		VictimPosition: Position{
+			X: toInt(r[11]),
+			Y: toInt(r[12]),
+			Z: toInt(r[13]),
+		},
+		Weapon: r[14],
+	}
+}
+
+func NewVoteStarted(ti time.Time, r []string) Message {
+	return VoteStarted{
+		Meta:    NewMeta(ti, TypeVoteStarted),
+		Player:  toPlayer(r[4:]),
+		Issue:   r[1],
+		Details: r[2],
+		From:    toInt(r[3]),
+	}
+}
+
+func NewVoteCast(ti time.Time, r []string) Message {
+	return VoteCast{
+		Meta:    NewMeta(ti, TypeVoteCast),
+		Player:  toPlayer(r[4:]),
+		Issue:   r[1],
+		Details: r[2],
+		From:    toInt(r[3]),
+		Option:  toInt(r[9]),
+	}
+}
+
+func NewVoteResult(ti time.Time, r []string) Message {
+	return VoteResult{
+		Meta:    NewMeta(ti, TypeVoteResult),
+		Issue:   r[2],
+		Details: r[3],
+		Passed:  r[1] == "succeeded",
+	}
+}
+
+func NewMatchPauseEnabled(ti time.Time, r []string) Message {
+	kind, side := pauseReason(r[1])
+	return MatchPauseEnabled{
+		Meta:   NewMeta(ti, TypeMatchPauseEnabled),
+		Reason: r[1],
+		Kind:   kind,
+		Side:   side,
+	}
+}
+
+func NewMatchPauseDisabled(ti time.Time, r []string) Message {
+	kind, side := pauseReason(r[1])
+	return MatchPauseDisabled{
+		Meta:   NewMeta(ti, TypeMatchPauseDisabled),
+		Reason: r[1],
+		Kind:   kind,
+		Side:   side,
+	}
+}
+
+// NewRoundStats converts the JSON of a round stats block, r[1] holds the
+// JSON with JSON_BEGIN and JSON_END removed. Other blocks and blocks which
+// can't be decoded are returned as Unknown.
+func NewRoundStats(ti time.Time, r []string) Message {
+	m, _ := newRoundStats(ti, r)
+	return m
+}
+
+// newRoundStats converts a round stats block like NewRoundStats, it
+// also returns an error for the first number which can't be converted
+func newRoundStats(ti time.Time, r []string) (Message, error) {
+	var block struct {
+		Name    string            `json:"name"`
+		Round   string            `json:"round_number"`
+		ScoreT  string            `json:"score_t"`
+		ScoreCT string            `json:"score_ct"`
+		Map     string            `json:"map"`
+		Server  string            `json:"server"`
+		Fields  string            `json:"fields"`
+		Players map[string]string `json:"players"`
+	}
+
+	if err := json.Unmarshal([]byte(trailingComma.ReplaceAllString(r[1], "$1")), &block); err != nil || block.Name != "round_stats" {
+		return NewUnknown(ti, r), nil
+	}
+
+	// older servers log less fields
+	c := converter{pattern: string(TypeRoundStats), optional: true}
+
+	rs := RoundStats{
+		Meta:    NewMeta(ti, TypeRoundStats),
+		Round:   c.toInt(block.Round),
+		ScoreT:  c.toInt(block.ScoreT),
+		ScoreCT: c.toInt(block.ScoreCT),
+		Map:     block.Map,
+		Server:  block.Server,
+		Fields:  splitStats(block.Fields),
+		Players: make([]PlayerRoundStats, len(block.Players)),
+	}
+
+	for i := range rs.Players {
+		values := splitStats(block.Players["player_"+strconv.Itoa(i)])
+
+		if values == nil {
+			return NewUnknown(ti, r), nil
+		}
+
+		stats := make(map[string]string, len(rs.Fields))
+		for j, f := range rs.Fields {
+			if j < len(values) {
+				stats[f] = values[j]
+			}
+		}
+
+		rs.Players[i] = PlayerRoundStats{
+			AccountID:       c.toInt(stats["accountid"]),
+			Side:            teamSide(stats["team"]),
+			Money:           c.toInt(stats["money"]),
+			Kills:           c.toInt(stats["kills"]),
+			Deaths:          c.toInt(stats["deaths"]),
+			Assists:         c.toInt(stats["assists"]),
+			Damage:          c.toInt(stats["dmg"]),
+			HeadshotPercent: c.toFloat32(stats["hsp"]),
+			KDR:             c.toFloat32(stats["kdr"]),
+			ADR:             c.toFloat32(stats["adr"]),
+			MVP:             c.toInt(stats["mvp"]),
+			EnemiesFlashed:  c.toInt(stats["ef"]),
+			UtilityDamage:   c.toInt(stats["ud"]),
+			Kills3:          c.toInt(stats["3k"]),
+			Kills4:          c.toInt(stats["4k"]),
+			Kills5:          c.toInt(stats["5k"]),
+			ClutchKills:     c.toInt(stats["clutchk"]),
+			FirstKills:      c.toInt(stats["firstk"]),
+			PistolKills:     c.toInt(stats["pistolk"]),
+			SniperKills:     c.toInt(stats["sniperk"]),
+			BlindKills:      c.toInt(stats["blindk"]),
+			BombKills:       c.toInt(stats["bombk"]),
+			FireDamage:      c.toInt(stats["firedmg"]),
+			UniqueKills:     c.toInt(stats["uniquek"]),
+			Dinks:           c.toInt(stats["dinks"]),
+			ChickenKills:    c.toInt(stats["chickenk"]),
+			Stats:           stats,
+		}
+	}
+
+	return rs, c.err
+}
+
+func NewWarmupStart(ti time.Time, r []string) Message {
+	return WarmupStart{NewMeta(ti, TypeWarmupStart)}
+}
+
+func NewWarmupEnd(ti time.Time, r []string) Message {
+	return WarmupEnd{NewMeta(ti, TypeWarmupEnd)}
+}
+
+func NewLoadingMap(ti time.Time, r []string) Message {
+	return LoadingMap{
+		Meta: NewMeta(ti, TypeLoadingMap),
+		Map:  r[1],
+	}
+}
+
+func NewStartedMap(ti time.Time, r []string) Message {
+	return StartedMap{
+		Meta: NewMeta(ti, TypeStartedMap),
+		Map:  r[1],
+		CRC:  r[2],
+	}
+}
+
+func NewLogFileStarted(ti time.Time, r []string) Message {
+	return LogFileStarted{
+		Meta:    NewMeta(ti, TypeLogFileStarted),
+		File:    r[1],
+		Game:    r[2],
+		Version: r[3],
+	}
+}
+
+func NewLogFileClosed(ti time.Time, r []string) Message {
+	return LogFileClosed{NewMeta(ti, TypeLogFileClosed)}
+}
+
+func NewPlayerHostageTouched(ti time.Time, r []string) Message {
+	return PlayerHostageTouched{
+		Meta:   NewMeta(ti, TypePlayerHostageTouched),
+		Player: toPlayer(r[1:]),
+	}
+}
+
+func NewPlayerHostageRescued(ti time.Time, r []string) Message {
+	return PlayerHostageRescued{
+		Meta:   NewMeta(ti, TypePlayerHostageRescued),
+		Player: toPlayer(r[1:]),
+	}
+}
+
+func NewPlayerHostageKilled(ti time.Time, r []string) Message {
+	return PlayerHostageKilled{
+		Meta:   NewMeta(ti, TypePlayerHostageKilled),
+		Player: toPlayer(r[1:]),
+	}
+}
+
+func NewPlayerNameChanged(ti time.Time, r []string) Message {
+	return PlayerNameChanged{
+		Meta:    NewMeta(ti, TypePlayerNameChanged),
+		Player:  toPlayer(r[1:]),
+		NewName: r[6],
+	}
+}
+
+func NewPlayerValidated(ti time.Time, r []string) Message {
+	return PlayerValidated{
+		Meta: NewMeta(ti, TypePlayerValidated),
+		Player: Player{
 			Name:    r[1],
 			ID:      toInt(r[2]),
 			SteamID: r[3],
-			Side:    r[4],
+			Side:    "",
 		},
-		AttackerPosition: Position{
-			X: toInt(r[5]),
-			Y: toInt(r[6]),
-			Z: toInt(r[7]),
+	}
+}
+
+func NewPlayerKicked(ti time.Time, r []string) Message {
+	return PlayerKicked{
+		Meta:    NewMeta(ti, TypePlayerKicked),
+		Player:  toPlayer(r[1:]),
+		By:      r[6],
+		Message: r[7],
+	}
+}
+
+func NewTeamPlaying(ti time.Time, r []string) Message {
+	return TeamPlaying{
+		Meta:        NewMeta(ti, TypeTeamPlaying),
+		Side:        r[2],
+		Team:        r[3],
+		MatchStatus: r[1] != "",
+	}
+}
+
+func NewMatchStatusScore(ti time.Time, r []string) Message {
+	return MatchStatusScore{
+		Meta:         NewMeta(ti, TypeMatchStatusScore),
+		ScoreCT:      toInt(r[1]),
+		ScoreT:       toInt(r[2]),
+		Map:          r[3],
+		RoundsPlayed: toInt(r[4]),
+	}
+}
+
+func NewAccolade(ti time.Time, r []string) Message {
+	return Accolade{
+		Meta: NewMeta(ti, TypeAccolade),
+		Kind: r[1],
+		Name: r[2],
+		Player: Player{
+			Name: r[3],
+			ID:   toInt(r[4]),
 		},
-		Victim:   r[8],
-		VictimID: r[9],
-		VictimPosition: Position{
-			X: toInt(r[10]),
-			Y: toInt(r[11]),
-			Z: toInt(r[12]),
+		Value:    toFloat32(r[5]),
+		Position: toInt(r[6]),
+		Score:    toFloat32(r[7]),
+	}
+}
+
+func NewPlayerBombBeginPlant(ti time.Time, r []string) Message {
+	return PlayerBombBeginPlant{
+		Meta:   NewMeta(ti, TypePlayerBombBeginPlant),
+		Player: toPlayer(r[1:]),
+		Site:   r[6],
+	}
+}
+
+func NewGrenadeDetonated(ti time.Time, r []string) Message {
+	return GrenadeDetonated{
+		Meta:     NewMeta(ti, TypeGrenadeDetonated),
+		Thrower:  toPlayer(r[1:]),
+		Kind:     r[6],
+		Entindex: toInt(r[7]),
+		Event:    r[8],
+		Position: Position{
+			X: toInt(r[9]),
+			Y: toInt(r[10]),
+			Z: toInt(r[11]),
 		},
-		Weapon: r[13],
+	}
+}
+
+func NewPlayerLeftBuyzone(ti time.Time, r []string) Message {
+	m, _ := newPlayerLeftBuyzone(ti, r)
+	return m
+}
+
+// checkPlayerLeftBuyzone checks the armor value within the list of items
+func checkPlayerLeftBuyzone(r []string) error {
+	_, err := newPlayerLeftBuyzone(time.Time{}, r)
+	return err
+}
+
+// newPlayerLeftBuyzone converts a match like NewPlayerLeftBuyzone, it
+// also returns an error if the armor value can't be converted
+func newPlayerLeftBuyzone(ti time.Time, r []string) (PlayerLeftBuyzone, error) {
+	c := converter{pattern: string(TypePlayerLeftBuyzone)}
+
+	m := PlayerLeftBuyzone{
+		Meta:   NewMeta(ti, TypePlayerLeftBuyzone),
+		Player: toPlayer(r[1:]),
+	}
+
+	for _, item := range strings.Fields(r[6]) {
+		switch {
+		case item == "helmet":
+			m.Helmet = true
+		case item == "defuser":
+			m.Defuser = true
+		case strings.HasPrefix(item, "kevlar(") && strings.HasSuffix(item, ")"):
+			m.Armor = c.toInt(item[len("kevlar(") : len(item)-1])
+		default:
+			m.Items = append(m.Items, item)
+		}
+	}
+
+	return m, c.err
+}
+
+func NewWorldNotice(ti time.Time, r []string) Message {
+	return WorldNotice{
+		Meta:   NewMeta(ti, TypeWorldNotice),
+		Notice: r[1],
 	}
 }
 
 func NewUnknown(ti time.Time, r []string) Message {
 	return Unknown{
-		Meta: NewMeta(ti, "Unknown"),
+		Meta: NewMeta(ti, TypeUnknown),
 		Raw:  r[1],
 	}
 }
 
 // helpers
 
+// pauseReason returns the kind of a match pause and the side
+// which called the timeout
+func pauseReason(reason string) (PauseKind, string) {
+	var kind PauseKind
+
+	switch {
+	case reason == "mp_pause_match" || reason == "mp_unpause_match":
+		return PauseAdmin, ""
+	case strings.HasPrefix(reason, "TimeOut"):
+		kind = PauseTactical
+	case strings.HasPrefix(reason, "Tech"):
+		kind = PauseTechnical
+	default:
+		return PauseUnknown, ""
+	}
+
+	switch {
+	case strings.HasSuffix(reason, "CTs"):
+		return kind, "CT"
+	case strings.HasSuffix(reason, "Ts"):
+		return kind, "TERRORIST"
+	}
+
+	return kind, ""
+}
+
+// toPlayer converts the submatches of a player tag starting at r[0]
+func toPlayer(r []string) Player {
+	return Player{
+		Name:     r[0],
+		ID:       toInt(r[1]),
+		SteamID:  r[2],
+		Side:     r[3],
+		Location: r[4],
+	}
+}
+
+// trailingComma matches a comma before the end of a JSON object
+var trailingComma = regexp.MustCompile(`,(\s*})`)
+
+// splitStats splits a comma separated list of round stats
+func splitStats(v string) []string {
+	if strings.TrimSpace(v) == "" {
+		return nil
+	}
+
+	list := strings.Split(v, ",")
+
+	for i := range list {
+		list[i] = strings.TrimSpace(list[i])
+	}
+
+	return list
+}
+
+// teamSide converts the team number of round stats to a side
+func teamSide(v string) string {
+	switch v {
+	case "2":
+		return "TERRORIST"
+	case "3":
+		return "CT"
+	}
+	return v
+}
+
+// checked sets the Check function of a pattern
+func checked(p Pattern, check func(r []string) error) Pattern {
+	p.Check = check
+	return p
+}
+
+// converter converts numbers like toInt and toFloat32 and keeps the first
+// error, it is used by MessageFuncs converting numbers the registry can't
+// check in strict mode. Empty values are no error if they are optional.
+type converter struct {
+	pattern  string
+	optional bool
+	err      error
+}
+
+func (c *converter) toInt(v string) int {
+	_, err := strconv.Atoi(v)
+	c.fail(v, err)
+	return toInt(v)
+}
+
+func (c *converter) toFloat32(v string) float32 {
+	_, err := strconv.ParseFloat(v, 32)
+	c.fail(v, err)
+	return toFloat32(v)
+}
+
+func (c *converter) fail(v string, err error) {
+	if err != nil && (v != "" || !c.optional) && c.err == nil {
+		c.err = &ConversionError{Pattern: c.pattern, Value: v, Err: err}
+	}
+}
+
 // toInt converts string to int, assigns 0 when not convertable
 func toInt(v string) int {
 
@@ -1,6 +1,7 @@
 package csgolog
 
 import (
+	"errors"
 	"fmt"
 	"regexp"
 	"strings"
@@ -467,6 +468,55 @@ func TestMessages(t *testing.T) {
 		assert(t, true, pk.Penetrated)
 	})
 
+	t.Run("PlayerKill Modifiers", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" [-225 -1829 -168] killed "Zim<20><BOT><CT>" [-476 -1709 -110] with "awp" (headshot penetrated throughsmoke attackerblind noscope attackerinair)`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerKill", m.GetType())
+
+		// when
+		pk, ok := m.(PlayerKill)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "awp", pk.Weapon)
+		assert(t, true, pk.Headshot)
+		assert(t, true, pk.Penetrated)
+		assert(t, true, pk.ThroughSmoke)
+		assert(t, true, pk.AttackerBlind)
+		assert(t, true, pk.NoScope)
+		assert(t, true, pk.AttackerInAir)
+		assert(t, "headshot penetrated throughsmoke attackerblind noscope attackerinair", strings.Join(pk.Modifiers, " "))
+	})
+
+	t.Run("PlayerKill unknown Modifier", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" [-225 -1829 -168] killed "Zim<20><BOT><CT>" [-476 -1709 -110] with "awp" (noscope foo)`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+
+		// when
+		pk, ok := m.(PlayerKill)
+
+		// then
+		assert(t, true, ok)
+		assert(t, false, pk.Headshot)
+		assert(t, true, pk.NoScope)
+		assert(t, 2, len(pk.Modifiers))
+		assert(t, "foo", pk.Modifiers[1])
+	})
+
 	t.Run("PlayerKillAssist", func(t *testing.T) {
 
 		// given
@@ -950,74 +1000,914 @@ func TestMessages(t *testing.T) {
 		assert(t, "for 15.00 minutes", pb.Duration)
 		assert(t, "Console", pb.By)
 	})
-}
 
-func TestToJSON(t *testing.T) {
+	t.Run("VoteStarted", func(t *testing.T) {
 
-	t.Run("Message to json", func(t *testing.T) {
+		// given
+		l := line(`Vote started "StartTimeOut " from #2 "416<16><STEAM_1:1:55894410><TERRORIST><Area 4>"`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "VoteStarted", m.GetType())
+
+		// when
+		vs, ok := m.(VoteStarted)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "416", vs.Player.Name)
+		assert(t, 16, vs.Player.ID)
+		assert(t, "STEAM_1:1:55894410", vs.Player.SteamID)
+		assert(t, "TERRORIST", vs.Player.Side)
+		assert(t, "Area 4", vs.Player.Location)
+		assert(t, "StartTimeOut", vs.Issue)
+		assert(t, "", vs.Details)
+		assert(t, 2, vs.From)
+	})
+
+	t.Run("VoteStarted without location", func(t *testing.T) {
 
 		// given
-		m, _ := Parse(line(`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" purchased "m4a1"`))
-		expected := strip(`{
-				"time": "2018-11-05T15:44:36Z",
-				"type": "PlayerPurchase",
-				"player": {
-					"name": 		"Player-Name",
-					"id": 			12,
-					"steam_id": "STEAM_1:1:0101011",
-					"side": 		"TERRORIST"
-				},
-				"item": "m4a1"
-			}`)
+		l := line(`Vote started "ChangeLevel de_dust2" from #3 "Player-Name<12><STEAM_1:1:0101011><CT>"`)
 
 		// when
-		jsn := strip(ToJSON(m))
+		m, err := Parse(l)
 
 		// then
-		assert(t, expected, jsn)
+		assert(t, nil, err)
+		assert(t, "VoteStarted", m.GetType())
+
+		// when
+		vs, ok := m.(VoteStarted)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "Player-Name", vs.Player.Name)
+		assert(t, "", vs.Player.Location)
+		assert(t, "ChangeLevel", vs.Issue)
+		assert(t, "de_dust2", vs.Details)
 	})
 
-	t.Run("nil Message to json", func(t *testing.T) {
+	t.Run("VoteCast", func(t *testing.T) {
 
 		// given
-		var m Message
+		l := line(`Vote cast "StartTimeOut " from #2 "416<16><STEAM_1:1:55894410><TERRORIST><Area 4>" option0`)
 
 		// when
-		jsn := ToJSON(m)
+		m, err := Parse(l)
 
 		// then
-		assert(t, "null", strip(jsn))
+		assert(t, nil, err)
+		assert(t, "VoteCast", m.GetType())
+
+		// when
+		vc, ok := m.(VoteCast)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "416", vc.Player.Name)
+		assert(t, "Area 4", vc.Player.Location)
+		assert(t, "StartTimeOut", vc.Issue)
+		assert(t, 2, vc.From)
+		assert(t, 0, vc.Option)
 	})
 
-}
+	t.Run("VoteResult", func(t *testing.T) {
 
-func TestParse(t *testing.T) {
+		// given
+		l := line(`Vote succeeded "StartTimeOut "`)
 
-	t.Run("time and type", func(t *testing.T) {
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "VoteResult", m.GetType())
+
+		// when
+		vr, ok := m.(VoteResult)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "StartTimeOut", vr.Issue)
+		assert(t, true, vr.Passed)
+
+		// when
+		m, _ = Parse(line(`Vote failed "Surrender "`))
+
+		// then
+		assert(t, "Surrender", m.(VoteResult).Issue)
+		assert(t, false, m.(VoteResult).Passed)
+	})
+
+	t.Run("Vote without details", func(t *testing.T) {
+
+		lines := map[string]MessageType{
+			`Vote started "Surrender" from #2 "Player-Name<12><STEAM_1:1:0101011><TERRORIST>"`:      TypeVoteStarted,
+			`Vote cast "Surrender" from #2 "Player-Name<12><STEAM_1:1:0101011><TERRORIST>" option1`: TypeVoteCast,
+			`Vote succeeded "Surrender"`: TypeVoteResult,
+			`Vote failed "Surrender"`:    TypeVoteResult,
+		}
+
+		for l, typ := range lines {
+
+			// when
+			m, err := Parse(line(l))
+
+			// then
+			assert(t, nil, err)
+			assert(t, typ, TypeOf(m))
+
+			var issue, details string
+			switch v := m.(type) {
+			case VoteStarted:
+				issue, details = v.Issue, v.Details
+			case VoteCast:
+				issue, details = v.Issue, v.Details
+			case VoteResult:
+				issue, details = v.Issue, v.Details
+			}
+			assert(t, "Surrender", issue)
+			assert(t, "", details)
+		}
+	})
+
+	t.Run("MatchPauseEnabled", func(t *testing.T) {
 
 		// given
-		l := `L 11/05/2018 - 15:44:36: "Player-Name<12><STEAM_1:1:0101011><TERRORIST>" purchased "m4a1"`
+		l := line(`Match pause is enabled - TimeOutTs`)
 
 		// when
 		m, err := Parse(l)
 
 		// then
 		assert(t, nil, err)
-		assert(t, "PlayerPurchase", m.GetType())
-		assert(t, time.Date(2018, time.November, 5, 15, 44, 36, 0, time.UTC), m.GetTime())
+		assert(t, "MatchPauseEnabled", m.GetType())
+
+		// when
+		mp, ok := m.(MatchPauseEnabled)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "TimeOutTs", mp.Reason)
+		assert(t, PauseTactical, mp.Kind)
+		assert(t, "TERRORIST", mp.Side)
 	})
 
-	t.Run("error", func(t *testing.T) {
+	t.Run("MatchPauseDisabled", func(t *testing.T) {
 
 		// given
-		l := `foo`
+		l := line(`Match pause is disabled - TimeOutCTs`)
 
 		// when
 		m, err := Parse(l)
 
 		// then
-		assert(t, ErrorNoMatch, err)
-		assert(t, nil, m)
+		assert(t, nil, err)
+		assert(t, "MatchPauseDisabled", m.GetType())
+
+		// when
+		mp, ok := m.(MatchPauseDisabled)
+
+		// then
+		assert(t, true, ok)
+		assert(t, PauseTactical, mp.Kind)
+		assert(t, "CT", mp.Side)
+	})
+
+	t.Run("MatchPause reasons", func(t *testing.T) {
+
+		reasons := map[string]PauseKind{
+			"mp_pause_match":     PauseAdmin,
+			"mp_unpause_match":   PauseAdmin,
+			"TechTimeOutCTs":     PauseTechnical,
+			"TechnicalTimeOutTs": PauseTechnical,
+			"Foo":                PauseUnknown,
+		}
+
+		for reason, kind := range reasons {
+
+			// when
+			m, err := Parse(line(`Match pause is enabled - ` + reason))
+
+			// then
+			assert(t, nil, err)
+			assert(t, kind, m.(MatchPauseEnabled).Kind)
+		}
+
+		// when
+		m, _ := Parse(line(`Match pause is enabled - mp_pause_match`))
+
+		// then
+		assert(t, "", m.(MatchPauseEnabled).Side)
+	})
+
+	t.Run("Player location", func(t *testing.T) {
+
+		// given
+		tag := regexp.MustCompile(`(<(TERRORIST|CT|Unassigned)?>)"`)
+		m, _ := Parse(line(`"Player-Name<12><STEAM_1:1:0101011><CT><BombsiteA>" purchased "m4a1"`))
+
+		// then
+		assert(t, "BombsiteA", m.(PlayerPurchase).Player.Location)
+		assert(t, "CT", m.(PlayerPurchase).Player.Side)
+
+		for _, l := range messageLines {
+
+			// given
+			withLocation := tag.ReplaceAllString(l, `$1<Long A>"`)
+
+			// validation happens before the player is in the game
+			if withLocation == l || strings.Contains(l, "USERID validated") {
+				continue
+			}
+
+			// when
+			m, err := Parse(line(l))
+			ml, errl := Parse(line(withLocation))
+
+			// then
+			assert(t, nil, err)
+			assert(t, nil, errl)
+			assert(t, m.GetType(), ml.GetType())
+
+			// when
+			formatted, _ := Format(ml)
+
+			// then
+			assert(t, line(withLocation), formatted+"\n")
+		}
+
+		// players without side
+		locations := map[string]string{
+			`"Player-Name<12><STEAM_1:1:0101011><Long A>" switched from team <CT> to <TERRORIST>`: "Long A",
+			`"Player-Name<12><STEAM_1:1:0101011>" switched from team <CT> to <TERRORIST>`:         "",
+			`"Player-Name<2><STEAM_1:0:12345><>" STEAM USERID validated`:                          "",
+		}
+
+		for l, location := range locations {
+
+			// when
+			m, err := Parse(line(l))
+
+			// then
+			assert(t, nil, err)
+
+			switch v := m.(type) {
+			case PlayerSwitched:
+				assert(t, location, v.Player.Location)
+				assert(t, "CT", v.From)
+				assert(t, "TERRORIST", v.To)
+			case PlayerValidated:
+				assert(t, location, v.Player.Location)
+			default:
+				t.Errorf("unexpected type %s", m.GetType())
+			}
+
+			// when
+			formatted, _ := Format(m)
+
+			// then
+			assert(t, line(l), formatted+"\n")
+		}
+	})
+
+	t.Run("WarmupStart", func(t *testing.T) {
+
+		// given
+		l := line(`World triggered "Warmup_Start"`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "WarmupStart", m.GetType())
+	})
+
+	t.Run("WarmupEnd", func(t *testing.T) {
+
+		// given
+		l := line(`World triggered "Warmup_End"`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "WarmupEnd", m.GetType())
+	})
+
+	t.Run("LoadingMap", func(t *testing.T) {
+
+		// given
+		l := line(`Loading map "de_dust2"`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "LoadingMap", m.GetType())
+
+		// when
+		lm, ok := m.(LoadingMap)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "de_dust2", lm.Map)
+	})
+
+	t.Run("StartedMap", func(t *testing.T) {
+
+		// given
+		l := line(`Started map "workshop/125438255/de_dust2" (CRC "-1437522543")`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "StartedMap", m.GetType())
+
+		// when
+		sm, ok := m.(StartedMap)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "workshop/125438255/de_dust2", sm.Map)
+		assert(t, "-1437522543", sm.CRC)
+	})
+
+	t.Run("LogFileStarted", func(t *testing.T) {
+
+		// given
+		l := line(`Log file started (file "logs/L000_000_000_000_0_201811121958_000.log") (game "/home/csgo/csgo") (version "7284")`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "LogFileStarted", m.GetType())
+
+		// when
+		lf, ok := m.(LogFileStarted)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "logs/L000_000_000_000_0_201811121958_000.log", lf.File)
+		assert(t, "/home/csgo/csgo", lf.Game)
+		assert(t, "7284", lf.Version)
+	})
+
+	t.Run("LogFileClosed", func(t *testing.T) {
+
+		// given
+		l := line(`Log file closed`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "LogFileClosed", m.GetType())
+	})
+
+	t.Run("PlayerHostageTouched", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<2><STEAM_1:1:0101011><CT>" triggered "Touched_A_Hostage"`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerHostageTouched", m.GetType())
+
+		// when
+		ph, ok := m.(PlayerHostageTouched)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "Player-Name", ph.Player.Name)
+		assert(t, "CT", ph.Player.Side)
+	})
+
+	t.Run("PlayerHostageRescued", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<2><STEAM_1:1:0101011><CT>" triggered "Rescued_A_Hostage"`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerHostageRescued", m.GetType())
+		assert(t, "Player-Name", m.(PlayerHostageRescued).Player.Name)
+	})
+
+	t.Run("PlayerHostageKilled", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<3><STEAM_1:1:0101012><TERRORIST>" triggered "Killed_A_Hostage"`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerHostageKilled", m.GetType())
+		assert(t, "TERRORIST", m.(PlayerHostageKilled).Player.Side)
+	})
+
+	t.Run("TeamNotice hostages", func(t *testing.T) {
+
+		// when
+		m, err := Parse(line(`Team "CT" triggered "SFUI_Notice_All_Hostages_Rescued" (CT "1") (T "0")`))
+
+		// then
+		assert(t, nil, err)
+		assert(t, NoticeAllHostagesRescued, m.(TeamNotice).Notice)
+
+		// when
+		m, err = Parse(line(`Team "CT" triggered "SFUI_Notice_Hostages_Rescued" (CT "2") (T "0")`))
+
+		// then
+		assert(t, nil, err)
+		assert(t, NoticeHostagesRescued, m.(TeamNotice).Notice)
+
+		// when
+		m, err = Parse(line(`Team "TERRORIST" triggered "SFUI_Notice_Hostages_Not_Rescued" (CT "1") (T "1")`))
+
+		// then
+		assert(t, nil, err)
+		assert(t, NoticeHostagesNotRescued, m.(TeamNotice).Notice)
+	})
+
+	t.Run("PlayerNameChanged", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<3><STEAM_1:1:0101011><CT>" changed name to "New-Name"`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerNameChanged", m.GetType())
+
+		// when
+		pn, ok := m.(PlayerNameChanged)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "Player-Name", pn.Player.Name)
+		assert(t, 3, pn.Player.ID)
+		assert(t, "STEAM_1:1:0101011", pn.Player.SteamID)
+		assert(t, "CT", pn.Player.Side)
+		assert(t, "New-Name", pn.NewName)
+	})
+
+	t.Run("PlayerValidated", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<3><STEAM_1:1:0101011>" STEAM USERID validated`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerValidated", m.GetType())
+
+		// when
+		pv, ok := m.(PlayerValidated)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "Player-Name", pv.Player.Name)
+		assert(t, 3, pv.Player.ID)
+		assert(t, "STEAM_1:1:0101011", pv.Player.SteamID)
+	})
+
+	t.Run("PlayerValidated empty side", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<2><STEAM_1:0:12345><>" STEAM USERID validated`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerValidated", m.GetType())
+
+		pv := m.(PlayerValidated)
+		assert(t, "Player-Name", pv.Player.Name)
+		assert(t, 2, pv.Player.ID)
+		assert(t, "STEAM_1:0:12345", pv.Player.SteamID)
+	})
+
+	t.Run("PlayerKicked", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<3><STEAM_1:1:0101011><>" was kicked by "Console" (message "Kicked by Console")`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerKicked", m.GetType())
+
+		// when
+		pk, ok := m.(PlayerKicked)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "Player-Name", pk.Player.Name)
+		assert(t, "", pk.Player.Side)
+		assert(t, "Console", pk.By)
+		assert(t, "Kicked by Console", pk.Message)
+	})
+
+	t.Run("TeamPlaying", func(t *testing.T) {
+
+		// given
+		l := line(`Team playing "CT": Natus Vincere`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "TeamPlaying", m.GetType())
+
+		// when
+		tp, ok := m.(TeamPlaying)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "CT", tp.Side)
+		assert(t, "Natus Vincere", tp.Team)
+		assert(t, false, tp.MatchStatus)
+	})
+
+	t.Run("TeamPlaying MatchStatus", func(t *testing.T) {
+
+		// given
+		l := line(`MatchStatus: Team playing "TERRORIST": Team Liquid`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "TeamPlaying", m.GetType())
+
+		// when
+		tp, ok := m.(TeamPlaying)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "TERRORIST", tp.Side)
+		assert(t, "Team Liquid", tp.Team)
+		assert(t, true, tp.MatchStatus)
+	})
+
+	t.Run("MatchStatusScore", func(t *testing.T) {
+
+		// given
+		l := line(`MatchStatus: Score: 3:5 on map "de_inferno" RoundsPlayed: 8`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "MatchStatusScore", m.GetType())
+
+		// when
+		ms, ok := m.(MatchStatusScore)
+
+		// then
+		assert(t, true, ok)
+		assert(t, 3, ms.ScoreCT)
+		assert(t, 5, ms.ScoreT)
+		assert(t, "de_inferno", ms.Map)
+		assert(t, 8, ms.RoundsPlayed)
+	})
+
+	t.Run("Accolade", func(t *testing.T) {
+
+		// given
+		l := line(`ACCOLADE, FINAL: {MVP},  Player<12>, VALUE: 4.000000, POS: 1, SCORE: 40.000000`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "Accolade", m.GetType())
+
+		// when
+		a, ok := m.(Accolade)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "FINAL", a.Kind)
+		assert(t, "MVP", a.Name)
+		assert(t, "Player", a.Player.Name)
+		assert(t, 12, a.Player.ID)
+		assert(t, float32(4), a.Value)
+		assert(t, 1, a.Position)
+		assert(t, float32(40), a.Score)
+	})
+
+	t.Run("Accolade with tabs", func(t *testing.T) {
+
+		// given
+		l := line("ACCOLADE, FINAL: {burndamage},\tPlayer Name<4>,\tVALUE: 229.000000,\tPOS: 2,\tSCORE: 33.333336")
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+
+		// when
+		a, ok := m.(Accolade)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "burndamage", a.Name)
+		assert(t, "Player Name", a.Player.Name)
+		assert(t, float32(229), a.Value)
+		assert(t, 2, a.Position)
+		assert(t, float32(33.333336), a.Score)
+	})
+
+	t.Run("PlayerBombBeginPlant", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Bomb_Begin_Plant" at bombsite A`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerBombBeginPlant", m.GetType())
+
+		// when
+		pb, ok := m.(PlayerBombBeginPlant)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "Player-Name", pb.Player.Name)
+		assert(t, "A", pb.Site)
+	})
+
+	t.Run("PlayerBombPlanted at bombsite", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Planted_The_Bomb" at bombsite B`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerBombPlanted", m.GetType())
+		assert(t, "B", m.(PlayerBombPlanted).Site)
+	})
+	t.Run("GrenadeDetonated", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" smokegrenade entindex 163 detonated at [-716 -1636 -170]`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "GrenadeDetonated", m.GetType())
+
+		// when
+		gd, ok := m.(GrenadeDetonated)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "Player-Name", gd.Thrower.Name)
+		assert(t, 12, gd.Thrower.ID)
+		assert(t, "STEAM_1:1:0101011", gd.Thrower.SteamID)
+		assert(t, "TERRORIST", gd.Thrower.Side)
+
+		assert(t, "smokegrenade", gd.Kind)
+		assert(t, 163, gd.Entindex)
+		assert(t, GrenadeEventDetonated, gd.Event)
+
+		assert(t, -716, gd.Position.X)
+		assert(t, -1636, gd.Position.Y)
+		assert(t, -170, gd.Position.Z)
+	})
+
+	t.Run("GrenadeDetonated extinguished", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" molotov entindex 170 extinguished at [1052 280 1616]`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+
+		gd := m.(GrenadeDetonated)
+		assert(t, "molotov", gd.Kind)
+		assert(t, 170, gd.Entindex)
+		assert(t, GrenadeEventExtinguished, gd.Event)
+	})
+
+	t.Run("GrenadeDetonated decoy", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<12><STEAM_1:1:0101011><CT>" decoy entindex 171 stopped at [-1 0 12]`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+
+		gd := m.(GrenadeDetonated)
+		assert(t, "decoy", gd.Kind)
+		assert(t, GrenadeEventStopped, gd.Event)
+		assert(t, -1, gd.Position.X)
+	})
+
+	t.Run("PlayerLeftBuyzone", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<2><STEAM_1:1:0101011><CT>" left buyzone with [ weapon_knife weapon_usp_silencer weapon_m4a1 weapon_smokegrenade kevlar(100) helmet defuser ]`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerLeftBuyzone", m.GetType())
+
+		// when
+		pl, ok := m.(PlayerLeftBuyzone)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "Player-Name", pl.Player.Name)
+		assert(t, 2, pl.Player.ID)
+		assert(t, "STEAM_1:1:0101011", pl.Player.SteamID)
+		assert(t, "CT", pl.Player.Side)
+
+		assert(t, "weapon_knife weapon_usp_silencer weapon_m4a1 weapon_smokegrenade", strings.Join(pl.Items, " "))
+		assert(t, 100, pl.Armor)
+		assert(t, true, pl.Helmet)
+		assert(t, true, pl.Defuser)
+	})
+
+	t.Run("PlayerLeftBuyzone without armor", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" left buyzone with [ weapon_knife_t weapon_glock ]`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+
+		pl := m.(PlayerLeftBuyzone)
+		assert(t, "weapon_knife_t weapon_glock", strings.Join(pl.Items, " "))
+		assert(t, 0, pl.Armor)
+		assert(t, false, pl.Helmet)
+		assert(t, false, pl.Defuser)
+	})
+
+	t.Run("PlayerLeftBuyzone empty", func(t *testing.T) {
+
+		// given
+		l := line(`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" left buyzone with [ ]`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, 0, len(m.(PlayerLeftBuyzone).Items))
+	})
+
+	t.Run("WorldNotice", func(t *testing.T) {
+
+		// given
+		l := line(`World triggered "SFUI_Notice_Target_Bombed"`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "WorldNotice", m.GetType())
+
+		// when
+		wn, ok := m.(WorldNotice)
+
+		// then
+		assert(t, true, ok)
+		assert(t, NoticeTargetBombed, wn.Notice)
+	})
+
+}
+
+func TestToJSON(t *testing.T) {
+
+	t.Run("Message to json", func(t *testing.T) {
+
+		// given
+		m, _ := Parse(line(`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" purchased "m4a1"`))
+		expected := strip(`{
+				"time": "2018-11-05T15:44:36Z",
+				"type": "PlayerPurchase",
+				"player": {
+					"name": 		"Player-Name",
+					"id": 			12,
+					"steam_id": "STEAM_1:1:0101011",
+					"side": 		"TERRORIST"
+				},
+				"item": "m4a1"
+			}`)
+
+		// when
+		jsn := strip(ToJSON(m))
+
+		// then
+		assert(t, expected, jsn)
+	})
+
+	t.Run("nil Message to json", func(t *testing.T) {
+
+		// given
+		var m Message
+
+		// when
+		jsn := ToJSON(m)
+
+		// then
+		assert(t, "null", strip(jsn))
+	})
+
+}
+
+func TestParse(t *testing.T) {
+
+	t.Run("time and type", func(t *testing.T) {
+
+		// given
+		l := `L 11/05/2018 - 15:44:36: "Player-Name<12><STEAM_1:1:0101011><TERRORIST>" purchased "m4a1"`
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerPurchase", m.GetType())
+		assert(t, time.Date(2018, time.November, 5, 15, 44, 36, 0, time.UTC), m.GetTime())
+	})
+
+	t.Run("error", func(t *testing.T) {
+
+		// given
+		l := `foo`
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, true, errors.Is(err, ErrorNoMatch))
+		assert(t, nil, m)
+
+		// when
+		pe, ok := err.(*ParseError)
+
+		// then
+		assert(t, true, ok)
+		assert(t, StageFraming, pe.Stage)
+		assert(t, "foo", pe.Line)
+		assert(t, "framing: no match", pe.Error())
 	})
 
 	t.Run("error parse date", func(t *testing.T) {
@@ -1030,7 +1920,8 @@ func TestParse(t *testing.T) {
 		m, err := Parse(l)
 
 		// then
-		assert(t, `parsing time "11/50/2018 - 15:44:36": day out of range`, err.Error())
+		assert(t, `timestamp: parsing time "11/50/2018 - 15:44:36": day out of range`, err.Error())
+		assert(t, StageTimestamp, err.(*ParseError).Stage)
 		assert(t, nil, m)
 	})
 
@@ -0,0 +1,57 @@
+package csgolog
+
+import (
+	"fmt"
+)
+
+// ParseStage is the stage of parsing a line at which an error occurred
+type ParseStage int
+
+const (
+	// StageFraming is the detection of the line framing, e.g. `L 11/05/2018 - 15:44:36: `
+	StageFraming ParseStage = iota + 1
+	// StageTimestamp is the parsing of the time of a line
+	StageTimestamp
+	// StageBody is the parsing of the message body into a Message
+	StageBody
+)
+
+// String returns the name of the stage
+func (s ParseStage) String() string {
+	switch s {
+	case StageFraming:
+		return "framing"
+	case StageTimestamp:
+		return "timestamp"
+	case StageBody:
+		return "body"
+	}
+	return "unknown"
+}
+
+// ParseError is returned when a line can't be parsed. It wraps the
+// underlying error, e.g. ErrorNoMatch if the line has no known framing,
+// which can be checked using errors.Is.
+type ParseError struct {
+	// Line is the raw line
+	Line string
+	// LineNumber is the number of the line when read by a Scanner, 0 otherwise
+	LineNumber int
+	// Stage is the stage at which parsing failed
+	Stage ParseStage
+	// Err is the cause of the error
+	Err error
+}
+
+// Error returns the error message including stage and line number
+func (e *ParseError) Error() string {
+	if e.LineNumber > 0 {
+		return fmt.Sprintf("line %d: %s: %v", e.LineNumber, e.Stage, e.Err)
+	}
+	return fmt.Sprintf("%s: %v", e.Stage, e.Err)
+}
+
+// Unwrap returns the cause of the error
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"os"
 
@@ -38,25 +37,20 @@ func main() {
 		os.Exit(1)
 	}
 
-	r := bufio.NewReader(file)
+	s := csgolog.NewScanner(file, csgolog.CollectErrors())
 
-	// read first line
-	l, _, err := r.ReadLine()
-
-	for err == nil {
-
-		// parse
-		m, errParse := csgolog.Parse(string(l))
+	for s.Scan() {
+		// print to stdout
+		fmt.Fprintf(os.Stdout, "%s", csgolog.ToJSON(s.Message()))
+	}
 
-		if errParse != nil {
-			// print parse errors to stderr
-			fmt.Fprintf(os.Stderr, "ERROR: %s", csgolog.ToJSON(m))
-		} else {
-			// print to stdout
-			fmt.Fprintf(os.Stdout, "%s", csgolog.ToJSON(m))
-		}
+	// print parse errors to stderr
+	for _, err := range s.Errors() {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+	}
 
-		// next line
-		l, _, err = r.ReadLine()
+	if err := s.Err(); err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+		os.Exit(1)
 	}
 }
@@ -0,0 +1,550 @@
+package csgolog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrorNoLogLine error when a message can't be formatted as log line
+var ErrorNoLogLine = errors.New("no log line")
+
+// LogLiner is implemented by messages which can be formatted as log line
+type LogLiner interface {
+	Message
+	LogLine() string
+}
+
+// Format returns the log line of a message, parsing the line
+// results in a message equal to m
+func Format(m Message) (string, error) {
+	if l, ok := m.(LogLiner); ok {
+		return l.LogLine(), nil
+	}
+	return "", ErrorNoLogLine
+}
+
+// String returns the line of the frame
+func (f Frame) String() string {
+	if f.Framing == FramingHTTP {
+		return f.Time.Format(httpTimeLayout) + " - " + f.Body
+	}
+	return "L " + f.Time.Format(logTimeLayout) + ": " + f.Body
+}
+
+// line returns the log line of a message body, times with fractions of
+// a second are formatted like lines of HTTP logging to keep milliseconds
+func (m Meta) line(body string) string {
+	f := Frame{Framing: FramingLog, Time: m.Time, Body: body}
+
+	if m.Time.Nanosecond() != 0 {
+		f.Framing = FramingHTTP
+	}
+
+	return f.String()
+}
+
+// LogLine returns the log line of the message
+func (m ServerMessage) LogLine() string {
+	return m.line(fmt.Sprintf(`server_message: "%s"`, m.Text))
+}
+
+// LogLine returns the log line of the message
+func (m FreezTimeStart) LogLine() string {
+	return m.line(`Starting Freeze period`)
+}
+
+// LogLine returns the log line of the message
+func (m WorldMatchStart) LogLine() string {
+	return m.line(fmt.Sprintf(`World triggered "Match_Start" on "%s"`, m.Map))
+}
+
+// LogLine returns the log line of the message
+func (m WorldRoundStart) LogLine() string {
+	return m.line(`World triggered "Round_Start"`)
+}
+
+// LogLine returns the log line of the message
+func (m WorldRoundRestart) LogLine() string {
+	return m.line(fmt.Sprintf(`World triggered "Restart_Round_(%d_second)"`, m.Timeleft))
+}
+
+// LogLine returns the log line of the message
+func (m WorldRoundEnd) LogLine() string {
+	return m.line(`World triggered "Round_End"`)
+}
+
+// LogLine returns the log line of the message
+func (m WorldGameCommencing) LogLine() string {
+	return m.line(`World triggered "Game_Commencing"`)
+}
+
+// LogLine returns the log line of the message
+func (m TeamScored) LogLine() string {
+	return m.line(fmt.Sprintf(`Team "%s" scored "%d" with "%d" players`, m.Side, m.Score, m.NumPlayers))
+}
+
+// LogLine returns the log line of the message
+func (m TeamNotice) LogLine() string {
+	return m.line(fmt.Sprintf(`Team "%s" triggered "%s" (CT "%d") (T "%d")`, m.Side, m.Notice, m.ScoreCT, m.ScoreT))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerConnected) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" connected, address "%s"`, m.Player.tag(), m.Address))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerDisconnected) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" disconnected (reason "%s")`, m.Player.tag(), m.Reason))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerEntered) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" entered the game`, m.Player.tag()))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerBanned) LogLine() string {
+	return m.line(fmt.Sprintf(`Banid: "%s" was banned "%s" by "%s"`, m.Player.tag(), m.Duration, m.By))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerSwitched) LogLine() string {
+	tag := fmt.Sprintf("%s<%d><%s>", m.Player.Name, m.Player.ID, m.Player.SteamID)
+
+	if m.Player.Location != "" {
+		tag += "<" + m.Player.Location + ">"
+	}
+
+	return m.line(fmt.Sprintf(`"%s" switched from team <%s> to <%s>`, tag, m.From, m.To))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerSay) LogLine() string {
+	say := "say"
+
+	if m.Team {
+		say = "say_team"
+	}
+
+	return m.line(fmt.Sprintf(`"%s" %s "%s"`, m.Player.tag(), say, m.Text))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerPurchase) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" purchased "%s"`, m.Player.tag(), m.Item))
+}
+
+// LogLine returns the log line of the message, the modifiers are taken
+// from Modifiers or from the fields of the modifiers if Modifiers is empty
+func (m PlayerKill) LogLine() string {
+	flags := m.Modifiers
+
+	if len(flags) == 0 {
+		for _, f := range []struct {
+			set  bool
+			name string
+		}{
+			{m.Headshot, "headshot"},
+			{m.Penetrated, "penetrated"},
+			{m.ThroughSmoke, "throughsmoke"},
+			{m.AttackerBlind, "attackerblind"},
+			{m.NoScope, "noscope"},
+			{m.AttackerInAir, "attackerinair"},
+		} {
+			if f.set {
+				flags = append(flags, f.name)
+			}
+		}
+	}
+
+	body := fmt.Sprintf(`"%s" %s killed "%s" %s with "%s"`, m.Attacker.tag(), m.AttackerPosition.tag(), m.Victim.tag(), m.VictimPosition.tag(), m.Weapon)
+
+	if len(flags) > 0 {
+		body += " (" + strings.Join(flags, " ") + ")"
+	}
+
+	return m.line(body)
+}
+
+// LogLine returns the log line of the message
+func (m PlayerKillAssist) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" assisted killing "%s"`, m.Attacker.tag(), m.Victim.tag()))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerAttack) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" %s attacked "%s" %s with "%s" (damage "%d") (damage_armor "%d") (health "%d") (armor "%d") (hitgroup "%s")`,
+		m.Attacker.tag(), m.AttackerPosition.tag(), m.Victim.tag(), m.VictimPosition.tag(), m.Weapon,
+		m.Damage, m.DamageArmor, m.Health, m.Armor, m.Hitgroup))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerKilledBomb) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" %s was killed by the bomb.`, m.Player.tag(), m.Position.tag()))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerKilledSuicide) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" %s committed suicide with "%s"`, m.Player.tag(), m.Position.tag(), m.With))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerPickedUp) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" picked up "%s"`, m.Player.tag(), m.Item))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerDropped) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" dropped "%s"`, m.Player.tag(), m.Item))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerMoneyChange) LogLine() string {
+	body := fmt.Sprintf(`"%s" money change %d%+d = $%d (tracked)`, m.Player.tag(), m.Equation.A, m.Equation.B, m.Equation.Result)
+
+	if m.Purchase != "" {
+		body += fmt.Sprintf(" (purchase: %s)", m.Purchase)
+	}
+
+	return m.line(body)
+}
+
+// LogLine returns the log line of the message
+func (m PlayerBombGot) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" triggered "Got_The_Bomb"`, m.Player.tag()))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerBombPlanted) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" triggered "Planted_The_Bomb"%s`, m.Player.tag(), bombsite(m.Site)))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerBombDropped) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" triggered "Dropped_The_Bomb"`, m.Player.tag()))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerBombBeginDefuse) LogLine() string {
+	kit := "With"
+
+	if !m.Kit {
+		kit = "Without"
+	}
+
+	return m.line(fmt.Sprintf(`"%s" triggered "Begin_Bomb_Defuse_%s_Kit"`, m.Player.tag(), kit))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerBombDefused) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" triggered "Defused_The_Bomb"`, m.Player.tag()))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerThrew) LogLine() string {
+	body := fmt.Sprintf(`"%s" threw %s %s`, m.Player.tag(), m.Grenade, m.Position.tag())
+
+	if m.Entindex != 0 {
+		body += fmt.Sprintf(" flashbang entindex %d)", m.Entindex)
+	}
+
+	return m.line(body)
+}
+
+// LogLine returns the log line of the message
+func (m PlayerBlinded) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" blinded for %s by "%s" from flashbang entindex %d`,
+		m.Victim.tag(), strconv.FormatFloat(float64(m.For), 'f', -1, 32), m.Attacker.tag(), m.Entindex))
+}
+
+// LogLine returns the log line of the message
+func (m ProjectileSpawned) LogLine() string {
+	return m.line(fmt.Sprintf(`Molotov projectile spawned at %s %s %s, velocity %s %s %s`,
+		formatFloat(m.Position.X), formatFloat(m.Position.Y), formatFloat(m.Position.Z),
+		formatFloat(m.Velocity.X), formatFloat(m.Velocity.Y), formatFloat(m.Velocity.Z)))
+}
+
+// LogLine returns the log line of the message
+func (m GameOver) LogLine() string {
+	return m.line(fmt.Sprintf(`Game Over: %s %s %s score %d:%d after %d min`, m.Mode, m.MapGroup, m.Map, m.ScoreCT, m.ScoreT, m.Duration))
+}
+
+// LogLine returns the log line of the message
+func (m ServerCvar) LogLine() string {
+	return m.line(fmt.Sprintf(`server_cvar: "%s" "%s"`, m.Key, m.Value))
+}
+
+// LogLine returns the log line of the message
+func (m Get5Event) LogLine() string {
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+	enc.Encode(m.Params)
+
+	return m.line(fmt.Sprintf(`get5_event: {"matchid":"%s","params":%s,"event":"%s"}`, m.Matchid, strings.TrimSpace(buf.String()), m.Event))
+}
+
+// LogLine returns the log line of the message
+func (m Rcon) LogLine() string {
+	return m.line(fmt.Sprintf(`rcon from "%s:%d": command "%s"`, m.IP, m.Port, m.Command))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerKillOther) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" %s killed other "%s<%s>" %s with "%s"`,
+		m.Attacker.tag(), m.AttackerPosition.tag(), m.Victim, m.VictimID, m.VictimPosition.tag(), m.Weapon))
+}
+
+// LogLine returns the log line of the message
+func (m VoteStarted) LogLine() string {
+	return m.line(fmt.Sprintf(`Vote started "%s" from #%d "%s"`, voteIssue(m.Issue, m.Details), m.From, m.Player.tag()))
+}
+
+// LogLine returns the log line of the message
+func (m VoteCast) LogLine() string {
+	return m.line(fmt.Sprintf(`Vote cast "%s" from #%d "%s" option%d`, voteIssue(m.Issue, m.Details), m.From, m.Player.tag(), m.Option))
+}
+
+// LogLine returns the log line of the message
+func (m VoteResult) LogLine() string {
+	result := "failed"
+
+	if m.Passed {
+		result = "succeeded"
+	}
+
+	return m.line(fmt.Sprintf(`Vote %s "%s"`, result, voteIssue(m.Issue, m.Details)))
+}
+
+// voteIssue returns the issue of a vote followed by its details if any
+func voteIssue(issue string, details string) string {
+	if details == "" {
+		return issue
+	}
+	return issue + " " + details
+}
+
+// LogLine returns the log line of the message
+func (m MatchPauseEnabled) LogLine() string {
+	return m.line(fmt.Sprintf(`Match pause is enabled - %s`, m.Reason))
+}
+
+// LogLine returns the log line of the message
+func (m MatchPauseDisabled) LogLine() string {
+	return m.line(fmt.Sprintf(`Match pause is disabled - %s`, m.Reason))
+}
+
+// LogLine returns the lines of the round stats block separated by
+// newlines, use a Parser to assemble the lines to a message again
+func (m RoundStats) LogLine() string {
+	fields := make([]string, len(m.Fields))
+	for i, f := range m.Fields {
+		fields[i] = fmt.Sprintf("%7s", f)
+	}
+
+	lines := []string{
+		m.line(blockBegin),
+		m.line(`"name": "round_stats",`),
+		m.line(fmt.Sprintf(`"round_number" : "%d",`, m.Round)),
+		m.line(fmt.Sprintf(`"score_t" : "%d",`, m.ScoreT)),
+		m.line(fmt.Sprintf(`"score_ct" : "%d",`, m.ScoreCT)),
+		m.line(fmt.Sprintf(`"map" : %s,`, quote(m.Map))),
+		m.line(fmt.Sprintf(`"server" : %s,`, quote(m.Server))),
+		m.line(fmt.Sprintf(`"fields" : %s,`, quote(strings.Join(fields, ",")))),
+		m.line(`"players" : {`),
+	}
+
+	for i, p := range m.Players {
+		values := make([]string, len(m.Fields))
+		for j, f := range m.Fields {
+			values[j] = fmt.Sprintf("%7s", p.Stats[f])
+		}
+
+		l := fmt.Sprintf(`"player_%d" : %s`, i, quote(strings.Join(values, ",")))
+
+		if i < len(m.Players)-1 {
+			l += ","
+		}
+
+		lines = append(lines, m.line(l))
+	}
+
+	lines = append(lines, m.line(blockEnd))
+
+	return strings.Join(lines, "\n")
+}
+
+// LogLine returns the log line of the message
+func (m WarmupStart) LogLine() string {
+	return m.line(`World triggered "Warmup_Start"`)
+}
+
+// LogLine returns the log line of the message
+func (m WarmupEnd) LogLine() string {
+	return m.line(`World triggered "Warmup_End"`)
+}
+
+// LogLine returns the log line of the message
+func (m LoadingMap) LogLine() string {
+	return m.line(fmt.Sprintf(`Loading map "%s"`, m.Map))
+}
+
+// LogLine returns the log line of the message
+func (m StartedMap) LogLine() string {
+	return m.line(fmt.Sprintf(`Started map "%s" (CRC "%s")`, m.Map, m.CRC))
+}
+
+// LogLine returns the log line of the message
+func (m LogFileStarted) LogLine() string {
+	return m.line(fmt.Sprintf(`Log file started (file "%s") (game "%s") (version "%s")`, m.File, m.Game, m.Version))
+}
+
+// LogLine returns the log line of the message
+func (m LogFileClosed) LogLine() string {
+	return m.line(`Log file closed`)
+}
+
+// LogLine returns the log line of the message
+func (m PlayerHostageTouched) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" triggered "Touched_A_Hostage"`, m.Player.tag()))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerHostageRescued) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" triggered "Rescued_A_Hostage"`, m.Player.tag()))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerHostageKilled) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" triggered "Killed_A_Hostage"`, m.Player.tag()))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerNameChanged) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" changed name to "%s"`, m.Player.tag(), m.NewName))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerValidated) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s<%d><%s><>" STEAM USERID validated`, m.Player.Name, m.Player.ID, m.Player.SteamID))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerKicked) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" was kicked by "%s" (message "%s")`, m.Player.tag(), m.By, m.Message))
+}
+
+// LogLine returns the log line of the message
+func (m TeamPlaying) LogLine() string {
+	prefix := ""
+
+	if m.MatchStatus {
+		prefix = "MatchStatus: "
+	}
+
+	return m.line(fmt.Sprintf(`%sTeam playing "%s": %s`, prefix, m.Side, m.Team))
+}
+
+// LogLine returns the log line of the message
+func (m MatchStatusScore) LogLine() string {
+	return m.line(fmt.Sprintf(`MatchStatus: Score: %d:%d on map "%s" RoundsPlayed: %d`, m.ScoreCT, m.ScoreT, m.Map, m.RoundsPlayed))
+}
+
+// LogLine returns the log line of the message
+func (m Accolade) LogLine() string {
+	return m.line(fmt.Sprintf("ACCOLADE, %s: {%s},\t%s<%d>,\tVALUE: %s,\tPOS: %d,\tSCORE: %s",
+		m.Kind, m.Name, m.Player.Name, m.Player.ID, formatFloat(m.Value), m.Position, formatFloat(m.Score)))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerBombBeginPlant) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" triggered "Bomb_Begin_Plant"%s`, m.Player.tag(), bombsite(m.Site)))
+}
+
+// LogLine returns the log line of the message
+func (m GrenadeDetonated) LogLine() string {
+	return m.line(fmt.Sprintf(`"%s" %s entindex %d %s at %s`,
+		m.Thrower.tag(), m.Kind, m.Entindex, m.Event, m.Position.tag()))
+}
+
+// LogLine returns the log line of the message
+func (m PlayerLeftBuyzone) LogLine() string {
+	items := append([]string{}, m.Items...)
+
+	if m.Armor != 0 {
+		items = append(items, fmt.Sprintf("kevlar(%d)", m.Armor))
+	}
+	if m.Helmet {
+		items = append(items, "helmet")
+	}
+	if m.Defuser {
+		items = append(items, "defuser")
+	}
+
+	body := "[ ]"
+	if len(items) > 0 {
+		body = "[ " + strings.Join(items, " ") + " ]"
+	}
+
+	return m.line(fmt.Sprintf(`"%s" left buyzone with %s`, m.Player.tag(), body))
+}
+
+// LogLine returns the log line of the message
+func (m WorldNotice) LogLine() string {
+	return m.line(fmt.Sprintf(`World triggered "%s"`, m.Notice))
+}
+
+// LogLine returns the log line of the message
+func (m Unknown) LogLine() string {
+	return m.line(m.Raw)
+}
+
+// helpers
+
+// tag returns the player as written in log lines without quotes
+func (p Player) tag() string {
+	if p.Location != "" {
+		return fmt.Sprintf("%s<%d><%s><%s><%s>", p.Name, p.ID, p.SteamID, p.Side, p.Location)
+	}
+	return fmt.Sprintf("%s<%d><%s><%s>", p.Name, p.ID, p.SteamID, p.Side)
+}
+
+// tag returns the position as written in log lines
+func (p Position) tag() string {
+	return fmt.Sprintf("[%d %d %d]", p.X, p.Y, p.Z)
+}
+
+// bombsite returns the bombsite suffix of bomb events
+func bombsite(site string) string {
+	if site == "" {
+		return ""
+	}
+	return " at bombsite " + site
+}
+
+// quote returns v as JSON string
+func quote(v string) string {
+	b, _ := json.Marshal(v)
+	return string(b)
+}
+
+// formatFloat formats v with six decimals like the server does,
+// more decimals are used if needed to keep the exact value
+func formatFloat(v float32) string {
+	s := strconv.FormatFloat(float64(v), 'f', 6, 32)
+
+	if toFloat32(s) != v {
+		s = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	}
+
+	if !strings.Contains(s, ".") {
+		s += ".0"
+	}
+
+	return s
+}
@@ -0,0 +1,193 @@
+package csgolog
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFormat(t *testing.T) {
+
+	t.Run("example log", func(t *testing.T) {
+
+		for i, l := range exampleLines(t) {
+
+			// given
+			m, err := Parse(l)
+			assert(t, nil, err)
+
+			// when
+			formatted, err := Format(m)
+
+			// then
+			assert(t, nil, err)
+
+			// when
+			have, err := Parse(formatted)
+
+			// then
+			assert(t, nil, err)
+
+			if !reflect.DeepEqual(m, have) {
+				t.Errorf("line %d: round trip failed\n\tline:\t%s\n\tformatted:\t%s", i+1, l, formatted)
+			}
+		}
+	})
+
+	for _, l := range messageLines {
+
+		t.Run(l, func(t *testing.T) {
+
+			// given
+			m, err := Parse(line(l))
+			assert(t, nil, err)
+
+			// when
+			formatted, err := Format(m)
+
+			// then
+			assert(t, nil, err)
+			assert(t, line(l), formatted+"\n")
+		})
+	}
+
+	t.Run("http", func(t *testing.T) {
+
+		// given
+		m, err := Parse(`11/05/2018 - 15:44:36.250 - Starting Freeze period`)
+		assert(t, nil, err)
+
+		// when
+		formatted, err := Format(m)
+
+		// then
+		assert(t, nil, err)
+		assert(t, `11/05/2018 - 15:44:36.250 - Starting Freeze period`, formatted)
+	})
+
+	t.Run("constructed message", func(t *testing.T) {
+
+		// given
+		m := PlayerPurchase{
+			Meta:   NewMeta(time.Date(2018, time.November, 5, 15, 44, 36, 0, time.UTC), "PlayerPurchase"),
+			Player: Player{Name: "Player-Name", ID: 12, SteamID: "STEAM_1:1:0101011", Side: "CT"},
+			Item:   "m4a1",
+		}
+
+		// when
+		formatted := m.LogLine()
+
+		// then
+		assert(t, line(`"Player-Name<12><STEAM_1:1:0101011><CT>" purchased "m4a1"`), formatted+"\n")
+	})
+
+	t.Run("kill modifiers from fields", func(t *testing.T) {
+
+		// given
+		m := PlayerKill{
+			Meta:     NewMeta(time.Date(2018, time.November, 5, 15, 44, 36, 0, time.UTC), TypePlayerKill),
+			Attacker: Player{Name: "Player-Name", ID: 12, SteamID: "STEAM_1:1:0101011", Side: "TERRORIST"},
+			Victim:   Player{Name: "Zim", ID: 20, SteamID: "BOT", Side: "CT"},
+			Weapon:   "awp",
+			Headshot: true,
+			NoScope:  true,
+		}
+
+		// when
+		formatted := m.LogLine()
+
+		// then
+		assert(t, line(`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" [0 0 0] killed "Zim<20><BOT><CT>" [0 0 0] with "awp" (headshot noscope)`), formatted+"\n")
+	})
+
+	t.Run("no log line", func(t *testing.T) {
+
+		// when
+		_, err := Format(Meta{})
+
+		// then
+		assert(t, ErrorNoLogLine, err)
+	})
+}
+
+// messageLines holds a line for each message type as written by the server
+var messageLines = []string{
+	`server_message: "quit"`,
+	`Starting Freeze period`,
+	`World triggered "Match_Start" on "de_cache"`,
+	`World triggered "Round_Start"`,
+	`World triggered "Restart_Round_(1_second)"`,
+	`World triggered "Round_End"`,
+	`World triggered "Game_Commencing"`,
+	`Team "CT" scored "1" with "5" players`,
+	`Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "3") (T "0")`,
+	`"Player-Name<12><STEAM_1:1:0101011><>" connected, address ""`,
+	`"Player-Name<12><STEAM_1:1:0101011><CT>" disconnected (reason "Disconnect")`,
+	`"Player-Name<12><STEAM_1:1:0101011><>" entered the game`,
+	`Banid: "Player-Name<12><STEAM_1:1:0101011><>" was banned "for 15.00 minutes" by "Console"`,
+	`"Player-Name<12><STEAM_1:1:0101011>" switched from team <TERRORIST> to <Spectator>`,
+	`"Player-Name<12><STEAM_1:1:0101011><CT>" say "gl hf"`,
+	`"Player-Name<12><STEAM_1:1:0101011><CT>" say_team "eco"`,
+	`"Player-Name<12><STEAM_1:1:0101011><CT>" purchased "m4a1"`,
+	`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" [-225 -1829 -168] killed "Zim<20><BOT><CT>" [-476 -1709 -110] with "glock"`,
+	`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" [-225 -1829 -168] killed "Zim<20><BOT><CT>" [-476 -1709 -110] with "glock" (headshot penetrated)`,
+	`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" [-225 -1829 -168] killed "Zim<20><BOT><CT>" [-476 -1709 -110] with "awp" (throughsmoke noscope)`,
+	`"Player-Name<10><STEAM_1:1:0101010><CT>" assisted killing "Player-Name<12><STEAM_1:1:0101011><TERRORIST>"`,
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" [480 -67 1782] attacked "Jon<9><BOT><CT>" [-134 362 1613] with "ak47" (damage "27") (damage_armor "3") (health "73") (armor "96") (hitgroup "left leg")`,
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" [480 -67 1782] was killed by the bomb.`,
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" [480 -67 1782] committed suicide with "hegrenade"`,
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" picked up "ump45"`,
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" dropped "knife"`,
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" money change 2050-1000 = $1050 (tracked) (purchase: weapon_mp9)`,
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" money change 800+300 = $1100 (tracked)`,
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Got_The_Bomb"`,
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Planted_The_Bomb"`,
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Dropped_The_Bomb"`,
+	`"Player-Name<2><STEAM_1:1:0101011><CT>" triggered "Begin_Bomb_Defuse_With_Kit"`,
+	`"Player-Name<2><STEAM_1:1:0101011><CT>" triggered "Begin_Bomb_Defuse_Without_Kit"`,
+	`"Player-Name<2><STEAM_1:1:0101011><CT>" triggered "Defused_The_Bomb"`,
+	`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" threw smokegrenade [-716 -1636 -170]`,
+	`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" threw flashbang [-716 -1636 -170] flashbang entindex 163)`,
+	`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" blinded for 3.45 by "Player-Name<10><STEAM_1:1:0101010><CT>" from flashbang entindex 163`,
+	`Molotov projectile spawned at -539.715820 -2332.986572 -100.142113, velocity -77.150497 126.038345 175.574585`,
+	`Game Over: competitive mg_de_cache de_cache score 16:1 after 33 min`,
+	`server_cvar: "mp_maxrounds" "30"`,
+	`get5_event: {"matchid":"1","params":{"map_number":1,"map_name":"de_cache","victim":"","attacker":"","winner":"","winner_side":""},"event":"going_live"}`,
+	`rcon from "127.0.0.1:51234": command "status"`,
+	`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" [-225 -1829 -168] killed other "func_breakable<178>" [-476 -1709 -110] with "glock"`,
+	`Vote started "Surrender" from #2 "416<16><STEAM_1:1:55894410><TERRORIST><Area 4>"`,
+	`Vote started "ChangeLevel de_dust2" from #3 "Player-Name<12><STEAM_1:1:0101011><CT>"`,
+	`Vote cast "Surrender" from #2 "416<16><STEAM_1:1:55894410><TERRORIST><Area 4>" option1`,
+	`Vote succeeded "Surrender"`,
+	`Vote failed "ChangeLevel de_dust2"`,
+	`Match pause is enabled - TimeOutTs`,
+	`Match pause is disabled - mp_unpause_match`,
+	`World triggered "Warmup_Start"`,
+	`World triggered "Warmup_End"`,
+	`Loading map "de_dust2"`,
+	`Started map "de_dust2" (CRC "-1437522543")`,
+	`Log file closed`,
+	`"Player-Name<2><STEAM_1:1:0101011><CT>" triggered "Touched_A_Hostage"`,
+	`"Player-Name<2><STEAM_1:1:0101011><CT>" triggered "Rescued_A_Hostage"`,
+	`"Player-Name<2><STEAM_1:1:0101011><CT>" triggered "Killed_A_Hostage"`,
+	`"Player-Name<3><STEAM_1:1:0101011><CT>" changed name to "New-Name"`,
+	`"Player-Name<3><STEAM_1:1:0101011><>" STEAM USERID validated`,
+	`"Player-Name<3><STEAM_1:1:0101011><Spectator>" was kicked by "Console" (message "")`,
+	`Team playing "CT": Natus Vincere`,
+	`MatchStatus: Team playing "TERRORIST": Team Liquid`,
+	`MatchStatus: Score: 3:5 on map "de_inferno" RoundsPlayed: 8`,
+	"ACCOLADE, FINAL: {MVP},\tPlayer<12>,\tVALUE: 4.000000,\tPOS: 1,\tSCORE: 40.000000",
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Bomb_Begin_Plant" at bombsite A`,
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Bomb_Begin_Plant"`,
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Planted_The_Bomb" at bombsite A`,
+	`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" smokegrenade entindex 163 detonated at [-716 -1636 -170]`,
+	`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" molotov entindex 170 extinguished at [1052 280 1616]`,
+	`"Player-Name<12><STEAM_1:1:0101011><CT>" decoy entindex 171 started at [-1 0 12]`,
+	`"Player-Name<2><STEAM_1:1:0101011><CT>" left buyzone with [ weapon_knife weapon_usp_silencer weapon_m4a1 kevlar(100) helmet defuser ]`,
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" left buyzone with [ weapon_knife_t weapon_glock kevlar(42) ]`,
+	`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" left buyzone with [ ]`,
+	`"Console<0><Console><Console>" say "restarting"`,
+	`"Zim<20><BOT><Unassigned>" dropped "ak47"`,
+	`World triggered "SFUI_Notice_Target_Bombed"`,
+	`Log file started (file "logs/L000_000_000_000_0_201811121958_000.log") (game "/home/csgo/csgo") (version "7284")`,
+}
@@ -0,0 +1,80 @@
+package csgolog
+
+import (
+	"time"
+)
+
+// Framing is the format of the beginning of a log line
+type Framing int
+
+const (
+	// FramingLog is used by logfiles and UDP logging (logaddress_add),
+	// e.g. `L 11/05/2018 - 15:44:36: ...`
+	FramingLog Framing = iota + 1
+	// FramingHTTP is used by HTTP logging (logaddress_add_http) and has
+	// millisecond precision, e.g. `11/05/2018 - 15:44:36.123 - ...`
+	FramingHTTP
+)
+
+const (
+	logTimeLayout  = "01/02/2006 - 15:04:05"
+	httpTimeLayout = "01/02/2006 - 15:04:05.000"
+)
+
+// String returns the name of the framing
+func (f Framing) String() string {
+	switch f {
+	case FramingLog:
+		return "log"
+	case FramingHTTP:
+		return "http"
+	}
+	return "unknown"
+}
+
+// Frame holds the parts of a log line surrounding the message
+type Frame struct {
+	Framing Framing
+	Time    time.Time
+	Body    string
+}
+
+// ParseFrame detects the framing of a log line and splits it into its time and
+// message body, the time is interpreted as UTC. It returns a *ParseError
+// wrapping ErrorNoMatch if the line has no known framing
+func ParseFrame(line string) (Frame, error) {
+	return parseFrame(line, time.UTC)
+}
+
+// parseFrame parses a line like ParseFrame, the time is interpreted
+// as local time of loc
+func parseFrame(line string, loc *time.Location) (Frame, error) {
+	framing, layout := FramingLog, logTimeLayout
+
+	// pattern for date, beginning of a log message
+	result := LogLinePattern.FindStringSubmatch(line)
+
+	if result == nil {
+		framing, layout = FramingHTTP, httpTimeLayout
+		result = HTTPLinePattern.FindStringSubmatch(line)
+	}
+
+	// if result set is empty, parsing failed, return error
+	if result == nil {
+		return Frame{}, &ParseError{Line: line, Stage: StageFraming, Err: ErrorNoMatch}
+	}
+
+	// parse time
+	ti, err := time.ParseInLocation(layout, result[1], loc)
+
+	// if parsing the date failed, return error
+	if err != nil {
+		return Frame{}, &ParseError{Line: line, Stage: StageTimestamp, Err: err}
+	}
+
+	return Frame{
+		Framing: framing,
+		Time:    ti,
+		Body:    result[2],
+	}, nil
+}
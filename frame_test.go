@@ -0,0 +1,104 @@
+package csgolog
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseFrame(t *testing.T) {
+
+	t.Run("log", func(t *testing.T) {
+
+		// when
+		f, err := ParseFrame(`L 11/05/2018 - 15:44:36: Starting Freeze period`)
+
+		// then
+		assert(t, nil, err)
+		assert(t, FramingLog, f.Framing)
+		assert(t, "log", f.Framing.String())
+		assert(t, time.Date(2018, time.November, 5, 15, 44, 36, 0, time.UTC), f.Time)
+		assert(t, "Starting Freeze period", f.Body)
+	})
+
+	t.Run("http", func(t *testing.T) {
+
+		// when
+		f, err := ParseFrame(`11/05/2018 - 15:44:36.123 - Starting Freeze period`)
+
+		// then
+		assert(t, nil, err)
+		assert(t, FramingHTTP, f.Framing)
+		assert(t, "http", f.Framing.String())
+		assert(t, time.Date(2018, time.November, 5, 15, 44, 36, 123000000, time.UTC), f.Time)
+		assert(t, "Starting Freeze period", f.Body)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+
+		// when
+		_, err := ParseFrame(`11/05/2018 - 15:44:36: Starting Freeze period`)
+
+		// then
+		assert(t, true, errors.Is(err, ErrorNoMatch))
+	})
+
+	t.Run("log prefix in chat", func(t *testing.T) {
+
+		// given
+		lines := map[string]Framing{
+			`11/05/2018 - 15:44:36.123 - "P<1><STEAM_1:0:1><CT>" say "L 01/01/2000 - 00:00:00: World triggered \"Round_Start\""`: FramingHTTP,
+			`L 11/05/2018 - 15:44:36: "P<1><STEAM_1:0:1><CT>" say "01/01/2000 - 00:00:00.000 - World triggered \"Round_Start\""`: FramingLog,
+		}
+
+		for l, framing := range lines {
+
+			// when
+			f, err := ParseFrame(l)
+
+			// then
+			assert(t, nil, err)
+			assert(t, framing, f.Framing)
+			assert(t, 2018, f.Time.Year())
+			assert(t, true, strings.HasPrefix(f.Body, `"P<1><STEAM_1:0:1><CT>" say`))
+
+			// when
+			m, err := Parse(l)
+
+			// then
+			assert(t, nil, err)
+			assert(t, "PlayerSay", m.GetType())
+		}
+	})
+
+	t.Run("parse http line", func(t *testing.T) {
+
+		// when
+		m, err := Parse(`11/05/2018 - 15:44:36.250 - "Player-Name<12><STEAM_1:1:0101011><CT>" purchased "m4a1"`)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerPurchase", m.GetType())
+		assert(t, "m4a1", m.(PlayerPurchase).Item)
+		assert(t, 250*time.Millisecond, time.Duration(m.GetTime().Nanosecond()))
+	})
+
+	t.Run("scanner framing", func(t *testing.T) {
+
+		// given
+		s := NewScanner(strings.NewReader("11/05/2018 - 15:44:36.250 - Starting Freeze period\n" + line(`Starting Freeze period`)))
+
+		// when
+		s.Scan()
+
+		// then
+		assert(t, FramingHTTP, s.Framing())
+
+		// when
+		s.Scan()
+
+		// then
+		assert(t, FramingLog, s.Framing())
+	})
+}
@@ -0,0 +1,63 @@
+package csgolog
+
+// GrenadeTracker links GrenadeDetonated messages of a stream of messages
+// to the PlayerThrew message of the grenade. Throws are linked by entindex
+// if it was logged with the throw, otherwise to the oldest throw of the
+// same grenade by the same player which is not linked yet.
+//
+// A GrenadeTracker is not safe for concurrent use, use a separate
+// GrenadeTracker for each server.
+type GrenadeTracker struct {
+	pending []PlayerThrew
+	linked  map[int]PlayerThrew
+}
+
+// Track adds a message to the tracker, for a GrenadeDetonated message it
+// returns the throw of the grenade and whether the throw is known
+func (t *GrenadeTracker) Track(m Message) (PlayerThrew, bool) {
+	switch m := m.(type) {
+	case PlayerThrew:
+		if m.Entindex == 0 {
+			t.pending = append(t.pending, m)
+			break
+		}
+		t.link(m.Entindex, m)
+	case GrenadeDetonated:
+		return t.throw(m)
+	case WorldRoundStart, FreezTimeStart:
+		// grenades don't outlast a round
+		t.pending = nil
+		t.linked = nil
+	}
+
+	return PlayerThrew{}, false
+}
+
+// throw returns the throw of the grenade m, a pending throw is linked
+// to the entindex of m for further events of the same grenade
+func (t *GrenadeTracker) throw(m GrenadeDetonated) (PlayerThrew, bool) {
+	if th, ok := t.linked[m.Entindex]; ok {
+		return th, true
+	}
+
+	for i, th := range t.pending {
+		if th.Player.ID != m.Thrower.ID || th.Grenade != m.Kind {
+			continue
+		}
+
+		t.pending = append(t.pending[:i], t.pending[i+1:]...)
+		t.link(m.Entindex, th)
+
+		return th, true
+	}
+
+	return PlayerThrew{}, false
+}
+
+// link remembers th as the throw of the grenade with entindex
+func (t *GrenadeTracker) link(entindex int, th PlayerThrew) {
+	if t.linked == nil {
+		t.linked = map[int]PlayerThrew{}
+	}
+	t.linked[entindex] = th
+}
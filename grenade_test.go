@@ -0,0 +1,84 @@
+package csgolog
+
+import (
+	"testing"
+)
+
+func TestGrenadeTracker(t *testing.T) {
+
+	track := func(tr *GrenadeTracker, lines ...string) (PlayerThrew, bool) {
+		var (
+			th PlayerThrew
+			ok bool
+		)
+		for _, l := range lines {
+			m, err := Parse(line(l))
+			assert(t, nil, err)
+			th, ok = tr.Track(m)
+		}
+		return th, ok
+	}
+
+	t.Run("by entindex", func(t *testing.T) {
+
+		// given
+		tr := &GrenadeTracker{}
+
+		// when
+		th, ok := track(tr,
+			`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" threw flashbang [-716 -1636 -170] flashbang entindex 163)`,
+			`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" threw flashbang [-700 -1600 -170] flashbang entindex 164)`,
+			`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" flashbang entindex 163 detonated at [-500 -1200 -100]`,
+		)
+
+		// then
+		assert(t, true, ok)
+		assert(t, 163, th.Entindex)
+		assert(t, -716, th.Position.X)
+	})
+
+	t.Run("by thrower", func(t *testing.T) {
+
+		// given
+		tr := &GrenadeTracker{}
+
+		// when
+		th, ok := track(tr,
+			`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" threw molotov [1 2 3]`,
+			`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" threw smokegrenade [4 5 6]`,
+			`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" smokegrenade entindex 170 detonated at [10 20 30]`,
+		)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "smokegrenade", th.Grenade)
+		assert(t, 4, th.Position.X)
+
+		// when
+		th, ok = track(tr,
+			`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" molotov entindex 171 detonated at [10 20 30]`,
+			`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" molotov entindex 171 extinguished at [10 20 30]`,
+		)
+
+		// then
+		assert(t, true, ok)
+		assert(t, "molotov", th.Grenade)
+		assert(t, 1, th.Position.X)
+	})
+
+	t.Run("unknown throw", func(t *testing.T) {
+
+		// given
+		tr := &GrenadeTracker{}
+
+		// when
+		_, ok := track(tr,
+			`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" threw decoy [1 2 3]`,
+			`World triggered "Round_Start"`,
+			`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" decoy entindex 172 started at [10 20 30]`,
+		)
+
+		// then
+		assert(t, false, ok)
+	})
+}
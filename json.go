@@ -0,0 +1,159 @@
+package csgolog
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sync"
+)
+
+var (
+	// ErrorUnknownType error when the type of a JSON message is not registered
+	ErrorUnknownType = errors.New("unknown type")
+	// ErrorDuplicateType error when a type name is already registered
+	ErrorDuplicateType = errors.New("duplicate type")
+	// ErrorInvalidPrototype error when a prototype is nil or
+	// neither a struct nor a pointer to a struct
+	ErrorInvalidPrototype = errors.New("invalid prototype")
+)
+
+// types maps Meta.Type to the concrete type of a message
+var types = struct {
+	sync.RWMutex
+	m map[MessageType]reflect.Type
+}{
+	m: map[MessageType]reflect.Type{
+		TypeServerMessage:         reflect.TypeOf(ServerMessage{}),
+		TypeFreezTimeStart:        reflect.TypeOf(FreezTimeStart{}),
+		TypeWorldMatchStart:       reflect.TypeOf(WorldMatchStart{}),
+		TypeWorldRoundStart:       reflect.TypeOf(WorldRoundStart{}),
+		TypeWorldRoundRestart:     reflect.TypeOf(WorldRoundRestart{}),
+		TypeWorldRoundEnd:         reflect.TypeOf(WorldRoundEnd{}),
+		TypeWorldGameCommencing:   reflect.TypeOf(WorldGameCommencing{}),
+		TypeTeamScored:            reflect.TypeOf(TeamScored{}),
+		TypeTeamNotice:            reflect.TypeOf(TeamNotice{}),
+		TypePlayerConnected:       reflect.TypeOf(PlayerConnected{}),
+		TypePlayerDisconnected:    reflect.TypeOf(PlayerDisconnected{}),
+		TypePlayerEntered:         reflect.TypeOf(PlayerEntered{}),
+		TypePlayerBanned:          reflect.TypeOf(PlayerBanned{}),
+		TypePlayerSwitched:        reflect.TypeOf(PlayerSwitched{}),
+		TypePlayerSay:             reflect.TypeOf(PlayerSay{}),
+		TypePlayerPurchase:        reflect.TypeOf(PlayerPurchase{}),
+		TypePlayerKill:            reflect.TypeOf(PlayerKill{}),
+		TypePlayerKillAssist:      reflect.TypeOf(PlayerKillAssist{}),
+		TypePlayerAttack:          reflect.TypeOf(PlayerAttack{}),
+		TypePlayerKilledBomb:      reflect.TypeOf(PlayerKilledBomb{}),
+		TypePlayerKilledSuicide:   reflect.TypeOf(PlayerKilledSuicide{}),
+		TypePlayerPickedUp:        reflect.TypeOf(PlayerPickedUp{}),
+		TypePlayerDropped:         reflect.TypeOf(PlayerDropped{}),
+		TypePlayerMoneyChange:     reflect.TypeOf(PlayerMoneyChange{}),
+		TypePlayerBombGot:         reflect.TypeOf(PlayerBombGot{}),
+		TypePlayerBombPlanted:     reflect.TypeOf(PlayerBombPlanted{}),
+		TypePlayerBombDropped:     reflect.TypeOf(PlayerBombDropped{}),
+		TypePlayerBombBeginDefuse: reflect.TypeOf(PlayerBombBeginDefuse{}),
+		TypePlayerBombDefused:     reflect.TypeOf(PlayerBombDefused{}),
+		TypePlayerThrew:           reflect.TypeOf(PlayerThrew{}),
+		TypePlayerBlinded:         reflect.TypeOf(PlayerBlinded{}),
+		TypeProjectileSpawned:     reflect.TypeOf(ProjectileSpawned{}),
+		TypeGameOver:              reflect.TypeOf(GameOver{}),
+		TypeServerCvar:            reflect.TypeOf(ServerCvar{}),
+		TypeGet5Event:             reflect.TypeOf(Get5Event{}),
+		TypeRcon:                  reflect.TypeOf(Rcon{}),
+		TypePlayerKillOther:       reflect.TypeOf(PlayerKillOther{}),
+		TypeVoteStarted:           reflect.TypeOf(VoteStarted{}),
+		TypeVoteCast:              reflect.TypeOf(VoteCast{}),
+		TypeVoteResult:            reflect.TypeOf(VoteResult{}),
+		TypeMatchPauseEnabled:     reflect.TypeOf(MatchPauseEnabled{}),
+		TypeMatchPauseDisabled:    reflect.TypeOf(MatchPauseDisabled{}),
+		TypeRoundStats:            reflect.TypeOf(RoundStats{}),
+		TypeWarmupStart:           reflect.TypeOf(WarmupStart{}),
+		TypeWarmupEnd:             reflect.TypeOf(WarmupEnd{}),
+		TypeLoadingMap:            reflect.TypeOf(LoadingMap{}),
+		TypeStartedMap:            reflect.TypeOf(StartedMap{}),
+		TypeLogFileStarted:        reflect.TypeOf(LogFileStarted{}),
+		TypeLogFileClosed:         reflect.TypeOf(LogFileClosed{}),
+		TypePlayerHostageTouched:  reflect.TypeOf(PlayerHostageTouched{}),
+		TypePlayerHostageRescued:  reflect.TypeOf(PlayerHostageRescued{}),
+		TypePlayerHostageKilled:   reflect.TypeOf(PlayerHostageKilled{}),
+		TypePlayerNameChanged:     reflect.TypeOf(PlayerNameChanged{}),
+		TypePlayerValidated:       reflect.TypeOf(PlayerValidated{}),
+		TypePlayerKicked:          reflect.TypeOf(PlayerKicked{}),
+		TypeTeamPlaying:           reflect.TypeOf(TeamPlaying{}),
+		TypeMatchStatusScore:      reflect.TypeOf(MatchStatusScore{}),
+		TypeAccolade:              reflect.TypeOf(Accolade{}),
+		TypePlayerBombBeginPlant:  reflect.TypeOf(PlayerBombBeginPlant{}),
+		TypeGrenadeDetonated:      reflect.TypeOf(GrenadeDetonated{}),
+		TypePlayerLeftBuyzone:     reflect.TypeOf(PlayerLeftBuyzone{}),
+		TypeWorldNotice:           reflect.TypeOf(WorldNotice{}),
+		TypeUnknown:               reflect.TypeOf(Unknown{}),
+	},
+}
+
+// RegisterType registers the concrete type of prototype for messages
+// with Meta.Type name, FromJSON decodes these messages into a value
+// of the same type. It returns ErrorDuplicateType if name is already
+// registered and ErrorInvalidPrototype if prototype is nil or not a
+// struct or pointer to a struct.
+func RegisterType(name MessageType, prototype Message) error {
+	t := reflect.TypeOf(prototype)
+
+	if t == nil {
+		return ErrorInvalidPrototype
+	}
+
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t.Kind() != reflect.Struct {
+		return ErrorInvalidPrototype
+	}
+
+	types.Lock()
+	defer types.Unlock()
+
+	if _, ok := types.m[name]; ok {
+		return ErrorDuplicateType
+	}
+
+	types.m[name] = reflect.TypeOf(prototype)
+
+	return nil
+}
+
+// FromJSON decodes a message encoded by ToJSON into its concrete type,
+// it returns ErrorUnknownType if the type of the message is not registered
+func FromJSON(data []byte) (Message, error) {
+	var meta Meta
+
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+
+	types.RLock()
+	t, ok := types.m[meta.Type]
+	types.RUnlock()
+
+	if !ok {
+		return nil, ErrorUnknownType
+	}
+
+	// prototypes may be pointers to structs
+	ptr := t.Kind() == reflect.Ptr
+
+	if ptr {
+		t = t.Elem()
+	}
+
+	v := reflect.New(t)
+
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		return nil, err
+	}
+
+	if ptr {
+		return v.Interface().(Message), nil
+	}
+
+	return v.Elem().Interface().(Message), nil
+}
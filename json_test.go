@@ -0,0 +1,130 @@
+package csgolog
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFromJSON(t *testing.T) {
+
+	t.Run("example log", func(t *testing.T) {
+
+		for i, l := range exampleLines(t) {
+
+			// given
+			m, err := Parse(l)
+			assert(t, nil, err)
+
+			// when
+			have, err := FromJSON([]byte(ToJSON(m)))
+
+			// then
+			assert(t, nil, err)
+
+			if !reflect.DeepEqual(m, have) {
+				t.Errorf("line %d: round trip failed\n\twanted:\t%#v\n\thave:\t%#v", i+1, m, have)
+			}
+		}
+	})
+
+	for _, l := range messageLines {
+
+		t.Run(l, func(t *testing.T) {
+
+			// given
+			m, err := Parse(line(l))
+			assert(t, nil, err)
+
+			// when
+			have, err := FromJSON([]byte(ToJSON(m)))
+
+			// then
+			assert(t, nil, err)
+			assert(t, reflect.TypeOf(m), reflect.TypeOf(have))
+			assert(t, true, reflect.DeepEqual(m, have))
+		})
+	}
+
+	t.Run("custom type", func(t *testing.T) {
+
+		// given
+		type Triggered struct {
+			Meta
+			Event string `json:"event"`
+		}
+
+		// the type stays registered if the test runs more than once
+		if err := RegisterType("Triggered", &Triggered{}); err != nil {
+			assert(t, ErrorDuplicateType, err)
+		}
+
+		m := &Triggered{
+			Meta:  NewMeta(time.Date(2018, time.November, 5, 15, 44, 36, 0, time.UTC), "Triggered"),
+			Event: "Got_The_Bomb",
+		}
+
+		// when
+		have, err := FromJSON([]byte(ToJSON(m)))
+
+		// then
+		assert(t, nil, err)
+		assert(t, "Got_The_Bomb", have.(*Triggered).Event)
+		assert(t, true, reflect.DeepEqual(m, have))
+	})
+
+	t.Run("duplicate type", func(t *testing.T) {
+
+		// when
+		err := RegisterType("PlayerKill", PlayerKill{})
+
+		// then
+		assert(t, ErrorDuplicateType, err)
+	})
+
+	t.Run("invalid prototype", func(t *testing.T) {
+
+		prototypes := []Message{nil, text("foo")}
+
+		for _, p := range prototypes {
+
+			// when
+			err := RegisterType("Invalid", p)
+
+			// then
+			assert(t, ErrorInvalidPrototype, err)
+		}
+
+		// when
+		_, err := FromJSON([]byte(`{"time":"2018-11-05T15:44:36Z","type":"Invalid"}`))
+
+		// then
+		assert(t, ErrorUnknownType, err)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+
+		// when
+		m, err := FromJSON([]byte(`{"time":"2018-11-05T15:44:36Z","type":"Foo"}`))
+
+		// then
+		assert(t, nil, m)
+		assert(t, ErrorUnknownType, err)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+
+		// when
+		_, err := FromJSON([]byte(`{"type":`))
+
+		// then
+		assert(t, true, err != nil)
+	})
+}
+
+// text is a message which is not a struct
+type text string
+
+func (t text) GetType() string { return string(t) }
+
+func (t text) GetTime() time.Time { return time.Time{} }
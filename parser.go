@@ -0,0 +1,372 @@
+package csgolog
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrorPending is returned by a Parser for the lines of a multi-line
+// block, the message is returned with the last line of the block
+var ErrorPending = errors.New("pending")
+
+// ErrorBlockTooLong error when a multi-line block isn't closed
+// within MaxBlockLines lines
+var ErrorBlockTooLong = errors.New("block too long")
+
+// ErrorBlockDiscarded error when a multi-line block is discarded because
+// a line which isn't part of it was read before its last line. It is
+// returned together with the message of this line.
+var ErrorBlockDiscarded = errors.New("block discarded")
+
+// MaxBlockLines is the maximum number of lines of a multi-line block
+const MaxBlockLines = 1024
+
+const (
+	blockBegin = "JSON_BEGIN{"
+	blockEnd   = "}}JSON_END"
+)
+
+// blockContent matches the lines within a block, i.e. a key
+// like `"round_number" : "3",` or a brace
+var blockContent = regexp.MustCompile(`^(?:"\w+"\s*:|[{}],?$)`)
+
+// ErrorConversion error when a MessageFunc could not convert a matched line
+// and returned an Unknown message instead
+var ErrorConversion = errors.New("conversion failed")
+
+// ConversionError is the cause of a ParseError in strict mode, it is returned
+// when a submatch of a pattern can't be converted to a number
+type ConversionError struct {
+	// Pattern is the name of the matching pattern
+	Pattern string
+	// Index is the index of the submatch, 0 if unknown
+	Index int
+	// Value is the submatch which could not be converted
+	Value string
+	// Err is the error returned by the conversion
+	Err error
+}
+
+// Error returns the error message including pattern and submatch
+func (e *ConversionError) Error() string {
+	if e.Index == 0 {
+		return fmt.Sprintf("%s: %v", e.Pattern, e.Err)
+	}
+	return fmt.Sprintf("%s: submatch %d %q: %v", e.Pattern, e.Index, e.Value, e.Err)
+}
+
+// Unwrap returns the error returned by the conversion
+func (e *ConversionError) Unwrap() error {
+	return e.Err
+}
+
+// ClockJumpKind is the kind of a clock jump
+type ClockJumpKind int
+
+const (
+	// ClockDST is a jump caused by a change of the UTC offset of the
+	// location, e.g. at the beginning or end of daylight saving time
+	ClockDST ClockJumpKind = iota + 1
+	// ClockBackwards is a jump to an earlier time which is not
+	// caused by a change of the UTC offset
+	ClockBackwards
+)
+
+// String returns the name of the kind
+func (k ClockJumpKind) String() string {
+	switch k {
+	case ClockDST:
+		return "dst"
+	case ClockBackwards:
+		return "backwards"
+	}
+	return "unknown"
+}
+
+// ClockJump describes a jump of the server clock between two lines
+type ClockJump struct {
+	Kind ClockJumpKind
+	// From is the time of the previous line
+	From time.Time
+	// To is the time of the line after the jump
+	To time.Time
+}
+
+// Parser parses log lines using the patterns of a registry. Unlike
+// the package level functions a Parser can be configured using options.
+//
+// A Parser keeps track of the time of the last line to detect clock jumps
+// and assembles multi-line blocks like round stats, use a separate Parser
+// for each server or stream of lines.
+type Parser struct {
+	registry *Registry
+	strict   bool
+	location *time.Location
+	offset   time.Duration
+	onJump   func(ClockJump)
+	blocks   bool
+
+	mu    sync.Mutex
+	last  time.Time
+	block *block
+}
+
+// block holds the lines of a multi-line block read so far
+type block struct {
+	time  time.Time
+	lines []string
+}
+
+// ParserOption configures a Parser
+type ParserOption func(*Parser)
+
+// Strict enables the strict mode of a parser. By default numbers which can't
+// be converted (e.g. because they overflow) are replaced with 0, in strict mode
+// a *ParseError wrapping a *ConversionError is returned instead.
+func Strict() ParserOption {
+	return func(p *Parser) {
+		p.strict = true
+	}
+}
+
+// WithLocation sets the location of the server, timestamps of lines are
+// interpreted as local time of loc. By default timestamps are UTC.
+//
+// When daylight saving time ends the wall clock repeats an hour, times
+// within this hour are ambiguous. The parser resolves them so the time
+// of successive lines doesn't jump backwards.
+func WithLocation(loc *time.Location) ParserOption {
+	return func(p *Parser) {
+		p.location = loc
+	}
+}
+
+// WithOffset shifts the timestamps of all lines by d,
+// e.g. to correct a server clock known to be off
+func WithOffset(d time.Duration) ParserOption {
+	return func(p *Parser) {
+		p.offset = d
+	}
+}
+
+// OnClockJump sets a function which is called when the time of a line
+// jumps backwards or the UTC offset of the location changes
+func OnClockJump(fn func(ClockJump)) ParserOption {
+	return func(p *Parser) {
+		p.onJump = fn
+	}
+}
+
+// NewParser returns a parser using the patterns of the registry,
+// DefaultPatterns is used if registry is nil
+func NewParser(registry *Registry, opts ...ParserOption) *Parser {
+	if registry == nil {
+		registry = DefaultPatterns
+	}
+
+	p := &Parser{
+		registry: registry,
+		blocks:   true,
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Parse parses a plain log message and returns
+// message type or error if there's no match.
+//
+// Lines of a multi-line block return ErrorPending, the message
+// of the block is returned when parsing its last line. If the last
+// line got lost the block is discarded with the first line which
+// isn't part of a block, this line is parsed as usual and its message
+// is returned with a *ParseError wrapping ErrorBlockDiscarded.
+func (p *Parser) Parse(line string) (Message, error) {
+	_, m, err := p.parseLine(line)
+	return m, err
+}
+
+// parseLine parses the framing of a line and its message body
+func (p *Parser) parseLine(line string) (Frame, Message, error) {
+	loc := p.location
+
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	f, err := parseFrame(line, loc)
+
+	if err != nil {
+		return f, nil, err
+	}
+
+	// ambiguous times are resolved on the wall clock of the server
+	f.Time = p.track(f.Time).Add(p.offset)
+
+	var discarded error
+
+	if p.blocks {
+		m, ok, err := p.collect(f)
+
+		if ok {
+			if err != nil && err != ErrorPending {
+				err = &ParseError{Line: line, Stage: StageBody, Err: err}
+			}
+			return f, m, err
+		}
+
+		if err != nil {
+			discarded = &ParseError{Line: line, Stage: StageBody, Err: err}
+		}
+	}
+
+	// check all patterns in order, return if a pattern matches
+	m, ok, err := p.registry.match(f.Time, f.Body, p.strict)
+
+	if err != nil {
+		return f, nil, &ParseError{Line: line, Stage: StageBody, Err: err}
+	}
+
+	if ok {
+		return f, m, discarded
+	}
+
+	// if there was no match above but format of the log message was correct
+	// it's a valid logline but pattern is not defined, return unknown type
+	return f, NewUnknown(f.Time, []string{line, f.Body}), discarded
+}
+
+// track compares the logged time ti with the time of the previous line,
+// it resolves ambiguous times and reports clock jumps
+func (p *Parser) track(ti time.Time) time.Time {
+	// no need to keep track if nobody is interested in jumps and all times are UTC
+	if p.onJump == nil && p.location == nil {
+		return ti
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	last := p.last
+
+	// the wall clock repeats an hour when daylight saving time ends, use
+	// the earlier time unless it is before the time of the previous line
+	if earlier, later, ok := ambiguous(ti); ok {
+		ti = earlier
+		if earlier.Before(last) {
+			ti = later
+		}
+	}
+
+	p.last = ti
+
+	switch {
+	case last.IsZero():
+	case ti.Before(last):
+		p.jump(ClockBackwards, last, ti)
+	case offsetOf(ti) != offsetOf(last):
+		p.jump(ClockDST, last, ti)
+	}
+
+	return ti
+}
+
+// collect adds lines to the current multi-line block, it returns false
+// if the line is not part of a block. A block is discarded when a line
+// is neither content nor end of the block, e.g. because the end was lost.
+func (p *Parser) collect(f Frame) (Message, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	body := strings.TrimSpace(f.Body)
+
+	switch {
+	case body == blockBegin:
+		p.block = &block{time: f.Time, lines: []string{"{"}}
+		return nil, true, ErrorPending
+	case p.block == nil:
+		return nil, false, nil
+	case strings.HasSuffix(body, blockEnd):
+		b := p.block
+		p.block = nil
+		b.lines = append(b.lines, strings.TrimSuffix(body, blockEnd)+"}}")
+		json := strings.Join(b.lines, "\n")
+		return p.roundStats(b.time, json)
+	case !blockContent.MatchString(body):
+		p.block = nil
+		return nil, false, ErrorBlockDiscarded
+	case len(p.block.lines) >= MaxBlockLines:
+		p.block = nil
+		return nil, true, ErrorBlockTooLong
+	}
+
+	p.block.lines = append(p.block.lines, body)
+
+	return nil, true, ErrorPending
+}
+
+// roundStats converts an assembled block, in strict mode
+// an error is returned if a number can't be converted
+func (p *Parser) roundStats(ti time.Time, json string) (Message, bool, error) {
+	m, err := newRoundStats(ti, []string{json, json})
+
+	if p.strict && err != nil {
+		return nil, true, err
+	}
+
+	return m, true, nil
+}
+
+// jump reports a jump between the times of two lines before the offset
+// is added, the reported times include the offset like the messages
+func (p *Parser) jump(kind ClockJumpKind, from time.Time, to time.Time) {
+	if p.onJump != nil {
+		p.onJump(ClockJump{Kind: kind, From: from.Add(p.offset), To: to.Add(p.offset)})
+	}
+}
+
+// ambiguous returns both times with the same wall clock as ti
+// if the wall clock of ti is ambiguous in its location
+func ambiguous(ti time.Time) (earlier time.Time, later time.Time, ok bool) {
+	offset := offsetOf(ti)
+
+	// UTC offsets never change more than once within a day
+	for _, d := range []time.Duration{-12 * time.Hour, 12 * time.Hour} {
+		other := offsetOf(ti.Add(d))
+
+		if other == offset {
+			continue
+		}
+
+		alt := ti.Add(time.Duration(offset-other) * time.Second)
+
+		if alt.Equal(ti) || !sameClock(alt, ti) {
+			continue
+		}
+
+		if alt.Before(ti) {
+			return alt, ti, true
+		}
+		return ti, alt, true
+	}
+
+	return ti, ti, false
+}
+
+func offsetOf(ti time.Time) int {
+	_, offset := ti.Zone()
+	return offset
+}
+
+func sameClock(a time.Time, b time.Time) bool {
+	ah, am, as := a.Clock()
+	bh, bm, bs := b.Clock()
+	return ah == bh && am == bm && as == bs && a.YearDay() == b.YearDay()
+}
@@ -0,0 +1,548 @@
+package csgolog
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParserStrict(t *testing.T) {
+
+	// a number overflowing int
+	const o = "99999999999999999999"
+
+	// lines holding a number which can't be converted for each message type
+	lines := map[string]string{
+		"WorldRoundRestart":     `World triggered "Restart_Round_(` + o + `_second)`,
+		"TeamScored":            `Team "CT" scored "` + o + `" with "5" players`,
+		"TeamNotice":            `Team "CT" triggered "SFUI_Notice_CTs_Win" (CT "` + o + `") (T "0")`,
+		"PlayerConnected":       `"Player-Name<` + o + `><STEAM_1:1:0101011><>" connected, address "foo"`,
+		"PlayerDisconnected":    `"Player-Name<` + o + `><STEAM_1:1:0101011><CT>" disconnected (reason "Disconnect")`,
+		"PlayerEntered":         `"Player-Name<` + o + `><STEAM_1:1:0101011><>" entered the game`,
+		"PlayerBanned":          `Banid: "Player-Name<` + o + `><STEAM_1:1:0101011><>" was banned "for 15.00 minutes" by "Console"`,
+		"PlayerSwitched":        `"Player-Name<` + o + `><STEAM_1:1:0101011>" switched from team <TERRORIST> to <Spectator>`,
+		"PlayerSay":             `"Player-Name<` + o + `><STEAM_1:1:0101011><CT>" say "gl hf"`,
+		"PlayerPurchase":        `"Player-Name<` + o + `><STEAM_1:1:0101011><CT>" purchased "m4a1"`,
+		"PlayerKill":            `"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" [-225 -1829 -168] killed "Zim<20><BOT><CT>" [-476 -1709 ` + o + `] with "glock"`,
+		"PlayerKillAssist":      `"Player-Name<10><STEAM_1:1:0101010><CT>" assisted killing "Player-Name<` + o + `><STEAM_1:1:0101011><TERRORIST>"`,
+		"PlayerAttack":          `"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" [480 -67 1782] attacked "Jon<9><BOT><CT>" [-134 362 1613] with "ak47" (damage "` + o + `") (damage_armor "3") (health "73") (armor "96") (hitgroup "chest")`,
+		"PlayerKilledBomb":      `"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" [` + o + ` -67 1782] was killed by the bomb.`,
+		"PlayerKilledSuicide":   `"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" [480 ` + o + ` 1782] committed suicide with "hegrenade"`,
+		"PlayerPickedUp":        `"Player-Name<` + o + `><STEAM_1:1:0101011><TERRORIST>" picked up "ump45"`,
+		"PlayerDropped":         `"Player-Name<` + o + `><STEAM_1:1:0101011><TERRORIST>" dropped "knife"`,
+		"PlayerMoneyChange":     `"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" money change 2050-` + o + ` = $1050 (tracked)`,
+		"PlayerBombGot":         `"Player-Name<` + o + `><STEAM_1:1:0101011><TERRORIST>" triggered "Got_The_Bomb"`,
+		"PlayerBombPlanted":     `"Player-Name<` + o + `><STEAM_1:1:0101011><TERRORIST>" triggered "Planted_The_Bomb"`,
+		"PlayerBombDropped":     `"Player-Name<` + o + `><STEAM_1:1:0101011><TERRORIST>" triggered "Dropped_The_Bomb"`,
+		"PlayerBombBeginDefuse": `"Player-Name<` + o + `><STEAM_1:1:0101011><CT>" triggered "Begin_Bomb_Defuse_With_Kit"`,
+		"PlayerBombDefused":     `"Player-Name<` + o + `><STEAM_1:1:0101011><CT>" triggered "Defused_The_Bomb"`,
+		"PlayerThrew":           `"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" threw flashbang [-716 -1636 -170] flashbang entindex ` + o + `)`,
+		"PlayerBlinded":         `"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" blinded for 3.4.5 by "Player-Name<10><STEAM_1:1:0101010><CT>" from flashbang entindex 163`,
+		"ProjectileSpawned":     `Molotov projectile spawned at -539.715820 -2332.986572 -100.142113, velocity -77.150497 1` + o + o + `.0 175.574585`,
+		"GameOver":              `Game Over: competitive mg_de_cache de_cache score 16:1 after ` + o + ` min`,
+		"Get5Event":             `get5_event: {"matchid":"1","params":{"map_number":"one"},"event":"series_start"}`,
+		"Rcon":                  `rcon from "127.0.0.1:` + o + `": command "status"`,
+		"PlayerKillOther":       `"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" [-225 -1829 -168] killed other "func_breakable<` + o + `>" [-476 -1709 -110] with "glock"`,
+	}
+
+	for typ, l := range lines {
+
+		t.Run(typ, func(t *testing.T) {
+
+			// when
+			m, err := NewParser(nil).Parse(line(l))
+
+			// then
+			assert(t, nil, err)
+
+			// Get5Event and Rcon fall back to Unknown
+			if typ != "Get5Event" && typ != "Rcon" {
+				assert(t, typ, m.GetType())
+			}
+
+			// when
+			m, err = NewParser(nil, Strict()).Parse(line(l))
+
+			// then
+			assert(t, nil, m)
+
+			var pe *ParseError
+			assert(t, true, errors.As(err, &pe))
+			assert(t, StageBody, pe.Stage)
+
+			var ce *ConversionError
+			assert(t, true, errors.As(err, &ce))
+			assert(t, typ, ce.Pattern)
+		})
+	}
+
+	t.Run("lenient", func(t *testing.T) {
+
+		// when
+		m, err := Parse(line(lines["PlayerAttack"]))
+
+		// then
+		assert(t, nil, err)
+		assert(t, 0, m.(PlayerAttack).Damage)
+		assert(t, 96, m.(PlayerAttack).Armor)
+	})
+
+	t.Run("conversion error", func(t *testing.T) {
+
+		// when
+		_, err := NewParser(nil, Strict()).Parse(line(lines["PlayerAttack"]))
+
+		// then
+		assert(t, true, errors.Is(err, strconv.ErrRange))
+		assert(t, `body: PlayerAttack: submatch 18 "`+o+`": strconv.Atoi: parsing "`+o+`": value out of range`, err.Error())
+	})
+
+	t.Run("conversion error in list", func(t *testing.T) {
+
+		for _, armor := range []string{"kevlar(" + o + ")", "kevlar(x)", "kevlar()"} {
+
+			// given
+			l := line(`"Player-Name<2><STEAM_1:1:0101011><CT>" left buyzone with [ weapon_knife ` + armor + ` helmet ]`)
+
+			// when
+			m, err := NewParser(nil).Parse(l)
+
+			// then
+			assert(t, nil, err)
+			assert(t, 0, m.(PlayerLeftBuyzone).Armor)
+
+			// when
+			_, err = NewParser(nil, Strict()).Parse(l)
+
+			// then
+			var ce *ConversionError
+			assert(t, true, errors.As(err, &ce))
+			assert(t, "PlayerLeftBuyzone", ce.Pattern)
+		}
+
+		// when
+		m, err := NewParser(nil, Strict()).Parse(line(`"Player-Name<2><STEAM_1:1:0101011><CT>" left buyzone with [ weapon_knife kevlar(100) ]`))
+
+		// then
+		assert(t, nil, err)
+		assert(t, 100, m.(PlayerLeftBuyzone).Armor)
+	})
+
+	t.Run("strict valid line", func(t *testing.T) {
+
+		// when
+		m, err := NewParser(nil, Strict()).Parse(line(`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" threw smokegrenade [-716 -1636 -170]`))
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerThrew", m.GetType())
+	})
+
+	t.Run("scanner with parser", func(t *testing.T) {
+
+		// given
+		s := NewScanner(strings.NewReader(line(lines["TeamScored"])), WithParser(NewParser(nil, Strict())))
+
+		// when
+		ok := s.Scan()
+
+		// then
+		assert(t, false, ok)
+		assert(t, 1, s.Err().(*ParseError).LineNumber)
+		assert(t, StageBody, s.Err().(*ParseError).Stage)
+	})
+}
+
+func TestParserTime(t *testing.T) {
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+
+	if err != nil {
+		t.Skip("time zone database not available:", err)
+	}
+
+	t.Run("location", func(t *testing.T) {
+
+		// given
+		p := NewParser(nil, WithLocation(berlin))
+
+		// when
+		m, err := p.Parse(line(`Starting Freeze period`))
+
+		// then
+		assert(t, nil, err)
+		assert(t, time.Date(2018, time.November, 5, 14, 44, 36, 0, time.UTC), m.GetTime().UTC())
+		assert(t, berlin, m.GetTime().Location())
+	})
+
+	t.Run("offset", func(t *testing.T) {
+
+		// given
+		p := NewParser(nil, WithOffset(-2*time.Hour))
+
+		// when
+		m, err := p.Parse(line(`Starting Freeze period`))
+
+		// then
+		assert(t, nil, err)
+		assert(t, time.Date(2018, time.November, 5, 13, 44, 36, 0, time.UTC), m.GetTime())
+	})
+
+	t.Run("end of daylight saving time", func(t *testing.T) {
+
+		// given
+		var jumps []ClockJump
+		p := NewParser(nil, WithLocation(berlin), OnClockJump(func(j ClockJump) {
+			jumps = append(jumps, j)
+		}))
+
+		// when
+		m1, _ := p.Parse(`L 10/28/2018 - 02:50:00: Starting Freeze period`)
+		m2, _ := p.Parse(`L 10/28/2018 - 02:10:00: Starting Freeze period`)
+		m3, _ := p.Parse(`L 10/28/2018 - 02:20:00: Starting Freeze period`)
+
+		// then
+		assert(t, time.Date(2018, time.October, 28, 0, 50, 0, 0, time.UTC), m1.GetTime().UTC())
+		assert(t, time.Date(2018, time.October, 28, 1, 10, 0, 0, time.UTC), m2.GetTime().UTC())
+		assert(t, time.Date(2018, time.October, 28, 1, 20, 0, 0, time.UTC), m3.GetTime().UTC())
+
+		assert(t, 1, len(jumps))
+		assert(t, ClockDST, jumps[0].Kind)
+		assert(t, m1.GetTime(), jumps[0].From)
+		assert(t, m2.GetTime(), jumps[0].To)
+	})
+
+	t.Run("end of daylight saving time with offset", func(t *testing.T) {
+
+		// given
+		var jumps []ClockJump
+		p := NewParser(nil, WithLocation(berlin), WithOffset(30*time.Minute), OnClockJump(func(j ClockJump) {
+			jumps = append(jumps, j)
+		}))
+
+		// when
+		m1, _ := p.Parse(`L 10/28/2018 - 02:20:00: Starting Freeze period`)
+		m2, _ := p.Parse(`L 10/28/2018 - 02:40:00: Starting Freeze period`)
+		m3, _ := p.Parse(`L 10/28/2018 - 02:10:00: Starting Freeze period`)
+
+		// then
+		assert(t, time.Date(2018, time.October, 28, 0, 50, 0, 0, time.UTC), m1.GetTime().UTC())
+		assert(t, time.Date(2018, time.October, 28, 1, 10, 0, 0, time.UTC), m2.GetTime().UTC())
+		assert(t, time.Date(2018, time.October, 28, 1, 40, 0, 0, time.UTC), m3.GetTime().UTC())
+
+		assert(t, 1, len(jumps))
+		assert(t, ClockDST, jumps[0].Kind)
+		assert(t, m2.GetTime(), jumps[0].From)
+		assert(t, m3.GetTime(), jumps[0].To)
+	})
+
+	t.Run("beginning of daylight saving time", func(t *testing.T) {
+
+		// given
+		var jumps []ClockJump
+		p := NewParser(nil, WithLocation(berlin), OnClockJump(func(j ClockJump) {
+			jumps = append(jumps, j)
+		}))
+
+		// when
+		m1, _ := p.Parse(`L 03/25/2018 - 01:59:59: Starting Freeze period`)
+		m2, _ := p.Parse(`L 03/25/2018 - 03:00:00: Starting Freeze period`)
+
+		// then
+		assert(t, time.Second, m2.GetTime().Sub(m1.GetTime()))
+		assert(t, 1, len(jumps))
+		assert(t, ClockDST, jumps[0].Kind)
+	})
+
+	t.Run("backwards", func(t *testing.T) {
+
+		// given
+		var jumps []ClockJump
+		p := NewParser(nil, OnClockJump(func(j ClockJump) {
+			jumps = append(jumps, j)
+		}))
+
+		// when
+		p.Parse(`L 11/05/2018 - 15:44:36: Starting Freeze period`)
+		p.Parse(`L 11/05/2018 - 15:40:00: Starting Freeze period`)
+		p.Parse(`L 11/05/2018 - 15:41:00: Starting Freeze period`)
+
+		// then
+		assert(t, 1, len(jumps))
+		assert(t, ClockBackwards, jumps[0].Kind)
+		assert(t, "backwards", jumps[0].Kind.String())
+		assert(t, time.Date(2018, time.November, 5, 15, 44, 36, 0, time.UTC), jumps[0].From)
+		assert(t, time.Date(2018, time.November, 5, 15, 40, 0, 0, time.UTC), jumps[0].To)
+	})
+
+	t.Run("new year", func(t *testing.T) {
+
+		// given
+		var jumps []ClockJump
+		p := NewParser(nil, WithLocation(berlin), OnClockJump(func(j ClockJump) {
+			jumps = append(jumps, j)
+		}))
+
+		// when
+		m1, _ := p.Parse(`L 12/31/2018 - 23:59:59: Starting Freeze period`)
+		m2, _ := p.Parse(`L 01/01/2019 - 00:00:01: Starting Freeze period`)
+
+		// then
+		assert(t, 2*time.Second, m2.GetTime().Sub(m1.GetTime()))
+		assert(t, 0, len(jumps))
+	})
+}
+
+func TestParserBlocks(t *testing.T) {
+
+	roundStats := line(`JSON_BEGIN{`) +
+		line(`"name": "round_stats",`) +
+		line(`"round_number" : "3",`) +
+		line(`"score_t" : "1",`) +
+		line(`"score_ct" : "2",`) +
+		line(`"map" : "de_inferno",`) +
+		line(`"server" : "Valve CS:GO Server",`) +
+		line(`"fields" : "             accountid,   team,  money,  kills, deaths,assists,    dmg,    hsp,    kdr,    adr,    mvp,     ef,     ud,     3k,     4k,     5k,clutchk, firstk,pistolk,sniperk, blindk,  bombk,firedmg,uniquek,  dinks,chickenk",`) +
+		line(`"players" : {`) +
+		line(`"player_0" : "      86754239,      3,   4250,      4,      1,      1,    412,  50.00,   4.00,    137,      2,      3,     24,      1,      0,      0,      0,      1,      1,      0,      0,      0,      0,      4,      2,      0",`) +
+		line(`"player_1" : "             0,      2,   1900,      1,      3,      0,    100,   0.00,   0.33,     33,      0,      0,      0,      0,      0,      0,      0,      0,      0,      0,      0,      0,      0,      1,      0,      1"`) +
+		line(`}}JSON_END`)
+
+	t.Run("round stats", func(t *testing.T) {
+
+		// given
+		s := NewScanner(strings.NewReader(line(`World triggered "Round_End"`) + roundStats + line(`Starting Freeze period`)))
+
+		// when
+		var types []string
+		for s.Scan() {
+			types = append(types, s.Message().GetType())
+		}
+
+		// then
+		assert(t, nil, s.Err())
+		assert(t, "WorldRoundEnd RoundStats FreezTimeStart", strings.Join(types, " "))
+	})
+
+	t.Run("players", func(t *testing.T) {
+
+		// given
+		p := NewParser(nil)
+		lines := strings.Split(strings.TrimSpace(roundStats), "\n")
+
+		for _, l := range lines[:len(lines)-1] {
+
+			// when
+			m, err := p.Parse(l)
+
+			// then
+			assert(t, nil, m)
+			assert(t, ErrorPending, err)
+		}
+
+		// when
+		m, err := p.Parse(lines[len(lines)-1])
+
+		// then
+		assert(t, nil, err)
+		assert(t, TypeRoundStats, TypeOf(m))
+
+		rs := m.(RoundStats)
+		assert(t, 3, rs.Round)
+		assert(t, 1, rs.ScoreT)
+		assert(t, 2, rs.ScoreCT)
+		assert(t, "de_inferno", rs.Map)
+		assert(t, "Valve CS:GO Server", rs.Server)
+		assert(t, 26, len(rs.Fields))
+		assert(t, 2, len(rs.Players))
+
+		p0 := rs.Players[0]
+		assert(t, 86754239, p0.AccountID)
+		assert(t, "CT", p0.Side)
+		assert(t, 4250, p0.Money)
+		assert(t, 4, p0.Kills)
+		assert(t, 1, p0.Deaths)
+		assert(t, 1, p0.Assists)
+		assert(t, 412, p0.Damage)
+		assert(t, float32(50), p0.HeadshotPercent)
+		assert(t, float32(4), p0.KDR)
+		assert(t, float32(137), p0.ADR)
+		assert(t, 2, p0.MVP)
+		assert(t, 3, p0.EnemiesFlashed)
+		assert(t, 24, p0.UtilityDamage)
+		assert(t, 1, p0.Kills3)
+		assert(t, 1, p0.FirstKills)
+		assert(t, 4, p0.UniqueKills)
+		assert(t, 2, p0.Dinks)
+		assert(t, "86754239", p0.Stats["accountid"])
+
+		p1 := rs.Players[1]
+		assert(t, "TERRORIST", p1.Side)
+		assert(t, float32(0.33), p1.KDR)
+		assert(t, 1, p1.ChickenKills)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+
+		// given
+		p := NewParser(nil)
+		var m Message
+		for _, l := range strings.Split(strings.TrimSpace(roundStats), "\n") {
+			m, _ = p.Parse(l)
+		}
+
+		// when
+		formatted, err := Format(m)
+
+		// then
+		assert(t, nil, err)
+
+		// when
+		var have Message
+		for _, l := range strings.Split(formatted, "\n") {
+			have, err = p.Parse(l)
+		}
+
+		// then
+		assert(t, nil, err)
+		assert(t, true, reflect.DeepEqual(m, have))
+
+		// when
+		have, err = FromJSON([]byte(ToJSON(m)))
+
+		// then
+		assert(t, nil, err)
+		assert(t, true, reflect.DeepEqual(m, have))
+	})
+
+	t.Run("other block", func(t *testing.T) {
+
+		// given
+		p := NewParser(nil)
+		p.Parse(line(`JSON_BEGIN{`))
+		p.Parse(line(`"name": "foo"`))
+
+		// when
+		m, err := p.Parse(line(`}}JSON_END`))
+
+		// then
+		assert(t, nil, err)
+		assert(t, TypeUnknown, TypeOf(m))
+	})
+
+	t.Run("block too long", func(t *testing.T) {
+
+		// given
+		p := NewParser(nil)
+		p.Parse(line(`JSON_BEGIN{`))
+		for i := 1; i < MaxBlockLines; i++ {
+			p.Parse(line(`"name": "foo",`))
+		}
+
+		// when
+		_, err := p.Parse(line(`"name": "foo",`))
+
+		// then
+		assert(t, true, errors.Is(err, ErrorBlockTooLong))
+
+		// when
+		m, err := p.Parse(line(`Starting Freeze period`))
+
+		// then
+		assert(t, nil, err)
+		assert(t, TypeFreezTimeStart, TypeOf(m))
+	})
+
+	t.Run("end lost", func(t *testing.T) {
+
+		// given
+		p := NewParser(nil)
+		lines := strings.Split(strings.TrimSpace(roundStats), "\n")
+
+		for _, l := range lines[:len(lines)-1] {
+			p.Parse(l)
+		}
+
+		// when
+		m, err := p.Parse(line(`"Player-Name<12><STEAM_1:1:0101011><CT>" purchased "m4a1"`))
+
+		// then
+		assert(t, true, errors.Is(err, ErrorBlockDiscarded))
+		assert(t, TypePlayerPurchase, TypeOf(m))
+
+		// when
+		m, err = p.Parse(line(`World triggered "Round_Start"`))
+
+		// then
+		assert(t, nil, err)
+		assert(t, TypeWorldRoundStart, TypeOf(m))
+
+		// when
+		m, err = p.Parse(lines[len(lines)-1])
+
+		// then
+		assert(t, nil, err)
+		assert(t, TypeUnknown, TypeOf(m))
+	})
+
+	t.Run("end lost scanner", func(t *testing.T) {
+
+		// given
+		lines := strings.Split(strings.TrimSpace(roundStats), "\n")
+		input := strings.Join(lines[:len(lines)-1], "\n") + "\n" + line(`Starting Freeze period`)
+		s := NewScanner(strings.NewReader(input), CollectErrors())
+
+		// when
+		ok := s.Scan()
+
+		// then
+		assert(t, true, ok)
+		assert(t, TypeFreezTimeStart, TypeOf(s.Message()))
+		assert(t, 1, len(s.Errors()))
+		assert(t, true, errors.Is(s.Errors()[0], ErrorBlockDiscarded))
+		assert(t, len(lines), s.Errors()[0].(*ParseError).LineNumber)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+
+		// given
+		block := strings.Replace(roundStats, `"round_number" : "3"`, `"round_number" : "3x"`, 1)
+		lines := strings.Split(strings.TrimSpace(block), "\n")
+
+		for strict, want := range map[bool]bool{false: false, true: true} {
+			var opts []ParserOption
+			if strict {
+				opts = append(opts, Strict())
+			}
+			p := NewParser(nil, opts...)
+
+			for _, l := range lines[:len(lines)-1] {
+				p.Parse(l)
+			}
+
+			// when
+			_, err := p.Parse(lines[len(lines)-1])
+
+			// then
+			var ce *ConversionError
+			assert(t, want, errors.As(err, &ce))
+			if want {
+				assert(t, "RoundStats", ce.Pattern)
+				assert(t, "3x", ce.Value)
+			}
+		}
+	})
+
+	t.Run("package level parse", func(t *testing.T) {
+
+		// when
+		m, err := Parse(line(`JSON_BEGIN{`))
+
+		// then
+		assert(t, nil, err)
+		assert(t, TypeUnknown, TypeOf(m))
+	})
+}
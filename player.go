@@ -0,0 +1,43 @@
+package csgolog
+
+// values of Player.SteamID which are not Steam IDs
+const (
+	// SteamIDBot is logged for bots and GOTV
+	SteamIDBot = "BOT"
+	// SteamIDConsole is logged for the server console, e.g. for say
+	SteamIDConsole = "Console"
+	// SteamIDPending is logged for players whose Steam ID
+	// wasn't validated yet
+	SteamIDPending = "STEAM_ID_PENDING"
+)
+
+// GOTVName is the default name of GOTV (tv_name), GOTV
+// is logged like a bot with this name
+const GOTVName = "GOTV"
+
+// IsBot returns whether the player is a bot, GOTV is not a bot
+func (p Player) IsBot() bool {
+	return p.SteamID == SteamIDBot && !p.IsGOTV()
+}
+
+// IsGOTV returns whether the player is GOTV, it is only detected
+// if the server uses the default name GOTVName
+func (p Player) IsGOTV() bool {
+	return p.SteamID == SteamIDBot && p.Name == GOTVName
+}
+
+// IsConsole returns whether the player is the server console
+func (p Player) IsConsole() bool {
+	return p.SteamID == SteamIDConsole
+}
+
+// IsHuman returns whether the player is a human with a known Steam ID.
+// Players whose Steam ID is empty or pending are neither human nor bot,
+// their identity is unknown.
+func (p Player) IsHuman() bool {
+	switch p.SteamID {
+	case "", SteamIDBot, SteamIDConsole, SteamIDPending:
+		return false
+	}
+	return true
+}
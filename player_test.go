@@ -0,0 +1,124 @@
+package csgolog
+
+import (
+	"testing"
+)
+
+func TestPlayer(t *testing.T) {
+
+	t.Run("identities", func(t *testing.T) {
+
+		players := map[Player][4]bool{
+			{Name: "Zim", ID: 20, SteamID: "BOT", Side: "CT"}:                       {true, false, false, false},
+			{Name: "GOTV", ID: 2, SteamID: "BOT", Side: ""}:                         {false, true, false, false},
+			{Name: "Console", ID: 0, SteamID: "Console", Side: "Console"}:           {false, false, true, false},
+			{Name: "Player-Name", ID: 12, SteamID: "STEAM_1:1:0101011", Side: "CT"}: {false, false, false, true},
+			{Name: "Player-Name", ID: 12, SteamID: "[U:1:202023]", Side: ""}:        {false, false, false, true},
+			{Name: "Player-Name", ID: 12, SteamID: "STEAM_ID_PENDING", Side: ""}:    {false, false, false, false},
+			{Name: "Player-Name", ID: 12, SteamID: "", Side: ""}:                    {false, false, false, false},
+		}
+
+		for p, want := range players {
+
+			// then
+			assert(t, want[0], p.IsBot())
+			assert(t, want[1], p.IsGOTV())
+			assert(t, want[2], p.IsConsole())
+			assert(t, want[3], p.IsHuman())
+		}
+	})
+
+	t.Run("bot log", func(t *testing.T) {
+
+		// given
+		lines := map[string]MessageType{
+			`"Zim<20><BOT><>" connected, address ""`:                                                                           TypePlayerConnected,
+			`"Zim<20><BOT><>" entered the game`:                                                                                TypePlayerEntered,
+			`"Zim<20><BOT>" switched from team <Unassigned> to <CT>`:                                                           TypePlayerSwitched,
+			`"Zim<20><BOT><CT>" purchased "m4a1"`:                                                                              TypePlayerPurchase,
+			`"Zim<20><BOT><CT>" left buyzone with [ weapon_knife weapon_hkp2000 weapon_m4a1 kevlar(100) helmet ]`:              TypePlayerLeftBuyzone,
+			`"Zim<20><BOT><CT>" [-476 -1709 -110] killed "Wyatt<10><BOT><TERRORIST>" [-225 -1829 -168] with "m4a1" (headshot)`: TypePlayerKill,
+			`"Zim<20><BOT><CT>" assisted killing "Wyatt<10><BOT><TERRORIST>"`:                                                  TypePlayerKillAssist,
+			`"Wyatt<10><BOT><TERRORIST>" dropped "ak47"`:                                                                       TypePlayerDropped,
+			`"Wyatt<10><BOT><Unassigned>" dropped "ak47"`:                                                                      TypePlayerDropped,
+			`"Zim<20><BOT><Spectator>" say "hi"`:                                                                               TypePlayerSay,
+			`"Console<0><Console><Console>" say "restarting"`:                                                                  TypePlayerSay,
+			`"Zim<20><BOT><Unassigned>" disconnected (reason "Kicked by Console")`:                                             TypePlayerDisconnected,
+			`"Zim<20><BOT><>" disconnected (reason "Kicked by Console")`:                                                       TypePlayerDisconnected,
+			`"Player-Name<2><[U:1:202023]><>" connected, address "127.0.0.1:27005"`:                                            TypePlayerConnected,
+			`"Player-Name<2><[U:1:202023]><CT>" purchased "m4a1"`:                                                              TypePlayerPurchase,
+			`"Player-Name<2><STEAM_ID_PENDING><>" entered the game`:                                                            TypePlayerEntered,
+			`"Player-Name<2><[U:1:202023]><Spectator>" changed name to "Other-Name"`:                                           TypePlayerNameChanged,
+			`"Player-Name<2><[U:1:202023]><Unassigned>" was kicked by "Console" (message "bye")`:                               TypePlayerKicked,
+		}
+
+		for l, typ := range lines {
+
+			// when
+			m, err := Parse(line(l))
+
+			// then
+			assert(t, nil, err)
+			assert(t, string(typ), m.GetType())
+		}
+	})
+
+	t.Run("gotv", func(t *testing.T) {
+
+		// when
+		m, err := Parse(line(`"GOTV<2><BOT><>" connected, address ""`))
+
+		// then
+		assert(t, nil, err)
+
+		p := m.(PlayerConnected).Player
+		assert(t, true, p.IsGOTV())
+		assert(t, false, p.IsBot())
+		assert(t, false, p.IsHuman())
+	})
+
+	t.Run("console say", func(t *testing.T) {
+
+		// given
+		l := line(`"Console<0><Console><Console>" say "restarting"`)
+
+		// when
+		m, err := Parse(l)
+
+		// then
+		assert(t, nil, err)
+
+		ps := m.(PlayerSay)
+		assert(t, true, ps.Player.IsConsole())
+		assert(t, "Console", ps.Player.Side)
+		assert(t, "restarting", ps.Text)
+	})
+
+	t.Run("example log", func(t *testing.T) {
+
+		// given
+		bots, humans := 0, 0
+
+		for _, l := range exampleLines(t) {
+			m, _ := Parse(l)
+
+			// when
+			pk, ok := m.(PlayerKill)
+
+			if !ok {
+				continue
+			}
+
+			// then
+			if pk.Victim.IsBot() {
+				bots++
+			}
+			if pk.Victim.IsHuman() {
+				humans++
+			}
+		}
+
+		assert(t, true, bots > 0)
+		assert(t, true, humans > 0)
+	})
+}
@@ -0,0 +1,143 @@
+package receiver
+
+import (
+	"crypto/subtle"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+)
+
+// DefaultMaxBodySize is the maximum size of a request body accepted by HTTPHandler
+const DefaultMaxBodySize = 8 * 1024 * 1024
+
+const (
+	// serverAddrHeader holds the address of the server posting the lines
+	serverAddrHeader = "X-Server-Addr"
+	// timestampHeader holds the time the server posted the lines
+	timestampHeader = "X-Timestamp"
+)
+
+// HTTPHandler is a http.Handler receiving log lines posted by servers
+// via `logaddress_add_http url`. Each request body holds one or more
+// lines, the server is identified by the X-Server-Addr header and by the
+// remote host of the request if the header is missing. The X-Timestamp
+// header is passed on as Entry.Timestamp.
+type HTTPHandler struct {
+	// stats is accessed atomically and must stay 64-bit aligned
+	stats    counters
+	handler  Handler
+	config   config
+	inFlight chan struct{}
+}
+
+// NewHTTPHandler returns a http.Handler passing received lines to h
+func NewHTTPHandler(h Handler, opts ...Option) *HTTPHandler {
+	handler := &HTTPHandler{
+		handler: h,
+		config:  newConfig(opts),
+	}
+
+	if handler.config.maxInFlight > 0 {
+		handler.inFlight = make(chan struct{}, handler.config.maxInFlight)
+	}
+
+	return handler
+}
+
+// ServeHTTP handles a request posting log lines, the handler is called
+// for every line before the response is written
+func (h *HTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	atomic.AddUint64(&h.stats.Packets, 1)
+
+	if r.Method != http.MethodPost {
+		atomic.AddUint64(&h.stats.Rejected, 1)
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.authorized(r) {
+		atomic.AddUint64(&h.stats.Rejected, 1)
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+
+	if h.inFlight != nil {
+		select {
+		case h.inFlight <- struct{}{}:
+			defer func() { <-h.inFlight }()
+		default:
+			atomic.AddUint64(&h.stats.Busy, 1)
+			w.Header().Set("Retry-After", "1")
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			return
+		}
+	}
+
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, h.config.maxBodySize))
+
+	// reading mostly fails if the body exceeds the maximum size
+	if err != nil {
+		atomic.AddUint64(&h.stats.Rejected, 1)
+		http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	server := r.Header.Get(serverAddrHeader)
+
+	// the port of the remote address changes with each connection
+	if server == "" {
+		server = r.RemoteAddr
+		if host, _, err := net.SplitHostPort(server); err == nil {
+			server = host
+		}
+	}
+
+	for _, l := range strings.Split(string(body), "\n") {
+		l = strings.TrimSuffix(l, "\r")
+
+		if l == "" {
+			continue
+		}
+
+		e, ok := h.config.parse(server, l)
+		e.Timestamp = r.Header.Get(timestampHeader)
+
+		atomic.AddUint64(&h.stats.Lines, 1)
+
+		// lines of a multi-line block are handled with its last line
+		if !ok {
+			continue
+		}
+
+		if e.Err != nil {
+			atomic.AddUint64(&h.stats.Errors, 1)
+		}
+
+		h.handler.HandleEntry(e)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Stats returns the current counters of the handler
+func (h *HTTPHandler) Stats() Stats {
+	return h.stats.snapshot()
+}
+
+// authorized reports whether the request holds the configured token
+func (h *HTTPHandler) authorized(r *http.Request) bool {
+	if h.config.token == "" {
+		return true
+	}
+
+	token := r.URL.Query().Get("token")
+
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	}
+
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.config.token)) == 1
+}
@@ -0,0 +1,253 @@
+package receiver
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	csgolog "github.com/FlowingSPDG/csgo-log"
+)
+
+func TestHTTPHandler(t *testing.T) {
+
+	body := "11/05/2018 - 15:44:36.123 - World triggered \"Round_Start\"\r\n" +
+		"11/05/2018 - 15:44:36.456 - \"Player-Name<12><STEAM_1:1:0101011><CT>\" purchased \"m4a1\"\n" +
+		"foo\n"
+
+	t.Run("post lines", func(t *testing.T) {
+
+		// given
+		var entries []Entry
+		h := NewHTTPHandler(HandlerFunc(func(e Entry) {
+			entries = append(entries, e)
+		}))
+
+		req := httptest.NewRequest(http.MethodPost, "/logs", strings.NewReader(body))
+		req.Header.Set("X-Server-Addr", "10.0.0.1:27015")
+		req.Header.Set("X-Timestamp", "1541432676")
+		rec := httptest.NewRecorder()
+
+		// when
+		h.ServeHTTP(rec, req)
+
+		// then
+		assert(t, http.StatusOK, rec.Code)
+		assert(t, 3, len(entries))
+
+		assert(t, "10.0.0.1:27015", entries[0].Server)
+		assert(t, "1541432676", entries[0].Timestamp)
+		assert(t, "WorldRoundStart", entries[0].Message.GetType())
+		assert(t, 123000000, entries[0].Message.GetTime().Nanosecond())
+
+		assert(t, "m4a1", entries[1].Message.(csgolog.PlayerPurchase).Item)
+
+		assert(t, true, errors.Is(entries[2].Err, csgolog.ErrorNoMatch))
+		assert(t, "foo", entries[2].Raw)
+
+		assert(t, Stats{Packets: 1, Lines: 3, Errors: 1}, h.Stats())
+	})
+
+	t.Run("remote address", func(t *testing.T) {
+
+		// given
+		entries := make(chan Entry, 10)
+		h := NewHTTPHandler(Chan(entries))
+
+		req := httptest.NewRequest(http.MethodPost, "/logs", strings.NewReader(body))
+		req.RemoteAddr = "10.0.0.2:1234"
+
+		// when
+		h.ServeHTTP(httptest.NewRecorder(), req)
+
+		// then
+		assert(t, "10.0.0.2", (<-entries).Server)
+	})
+
+	t.Run("parser per server", func(t *testing.T) {
+
+		// given
+		var servers []string
+		entries := make(chan Entry, 10)
+		h := NewHTTPHandler(Chan(entries), WithParser(func(server string) *csgolog.Parser {
+			servers = append(servers, server)
+			return csgolog.NewParser(nil, csgolog.WithOffset(time.Hour))
+		}))
+
+		for i := 0; i < 2; i++ {
+			req := httptest.NewRequest(http.MethodPost, "/logs", strings.NewReader(body))
+			req.Header.Set("X-Server-Addr", "10.0.0.1:27015")
+
+			// when
+			h.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		// then
+		assert(t, 1, len(servers))
+		assert(t, "10.0.0.1:27015", servers[0])
+		assert(t, 16, (<-entries).Message.GetTime().Hour())
+	})
+
+	t.Run("max servers", func(t *testing.T) {
+
+		// given
+		var servers []string
+		h := NewHTTPHandler(HandlerFunc(func(Entry) {}), WithMaxServers(2), WithParser(func(server string) *csgolog.Parser {
+			servers = append(servers, server)
+			return csgolog.NewParser(nil)
+		}))
+
+		for _, server := range []string{"a", "b", "a", "c", "a", "b"} {
+			req := httptest.NewRequest(http.MethodPost, "/logs", strings.NewReader(body))
+			req.Header.Set("X-Server-Addr", server)
+
+			// when
+			h.ServeHTTP(httptest.NewRecorder(), req)
+		}
+
+		// then
+		assert(t, "a b c b", strings.Join(servers, " "))
+	})
+
+	t.Run("multi-line block", func(t *testing.T) {
+
+		// given
+		var entries []Entry
+		h := NewHTTPHandler(HandlerFunc(func(e Entry) {
+			entries = append(entries, e)
+		}))
+
+		block := "11/05/2018 - 15:44:36.123 - JSON_BEGIN{\n" +
+			"11/05/2018 - 15:44:36.123 - \"name\": \"round_stats\",\n" +
+			"11/05/2018 - 15:44:36.123 - \"round_number\" : \"1\",\n" +
+			"11/05/2018 - 15:44:36.123 - \"players\" : {\n" +
+			"11/05/2018 - 15:44:36.123 - }}JSON_END\n"
+
+		// when
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/logs", strings.NewReader(block)))
+
+		// then
+		assert(t, 1, len(entries))
+		assert(t, nil, entries[0].Err)
+		assert(t, 1, entries[0].Message.(csgolog.RoundStats).Round)
+		assert(t, Stats{Packets: 1, Lines: 5}, h.Stats())
+	})
+
+	t.Run("multi-line block discarded", func(t *testing.T) {
+
+		// given
+		var entries []Entry
+		h := NewHTTPHandler(HandlerFunc(func(e Entry) {
+			entries = append(entries, e)
+		}))
+
+		block := "11/05/2018 - 15:44:36.123 - JSON_BEGIN{\n" +
+			"11/05/2018 - 15:44:36.123 - \"name\": \"round_stats\",\n" +
+			"11/05/2018 - 15:44:37.000 - World triggered \"Round_Start\"\n"
+
+		// when
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/logs", strings.NewReader(block)))
+
+		// then
+		assert(t, 1, len(entries))
+		assert(t, true, errors.Is(entries[0].Err, csgolog.ErrorBlockDiscarded))
+		assert(t, "WorldRoundStart", entries[0].Message.GetType())
+		assert(t, Stats{Packets: 1, Lines: 3, Errors: 1}, h.Stats())
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+
+		// given
+		h := NewHTTPHandler(HandlerFunc(func(Entry) {}))
+		rec := httptest.NewRecorder()
+
+		// when
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/logs", nil))
+
+		// then
+		assert(t, http.StatusMethodNotAllowed, rec.Code)
+		assert(t, Stats{Packets: 1, Rejected: 1}, h.Stats())
+	})
+
+	t.Run("token", func(t *testing.T) {
+
+		// given
+		h := NewHTTPHandler(HandlerFunc(func(Entry) {}), WithToken("s3cret"))
+
+		requests := map[*http.Request]int{
+			httptest.NewRequest(http.MethodPost, "/logs", strings.NewReader(body)):              http.StatusUnauthorized,
+			httptest.NewRequest(http.MethodPost, "/logs?token=wrong", strings.NewReader(body)):  http.StatusUnauthorized,
+			httptest.NewRequest(http.MethodPost, "/logs?token=s3cret", strings.NewReader(body)): http.StatusOK,
+		}
+
+		bearer := httptest.NewRequest(http.MethodPost, "/logs", strings.NewReader(body))
+		bearer.Header.Set("Authorization", "Bearer s3cret")
+		requests[bearer] = http.StatusOK
+
+		for req, code := range requests {
+
+			// when
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			// then
+			assert(t, code, rec.Code)
+		}
+
+		assert(t, uint64(2), h.Stats().Rejected)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+
+		// given
+		h := NewHTTPHandler(HandlerFunc(func(Entry) {}), WithMaxBodySize(10))
+		rec := httptest.NewRecorder()
+
+		// when
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/logs", strings.NewReader(body)))
+
+		// then
+		assert(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+
+	t.Run("max in flight", func(t *testing.T) {
+
+		// given
+		blocked := make(chan struct{})
+		release := make(chan struct{})
+		h := NewHTTPHandler(HandlerFunc(func(Entry) {
+			blocked <- struct{}{}
+			<-release
+		}), WithMaxInFlight(1))
+
+		srv := httptest.NewServer(h)
+		defer srv.Close()
+
+		done := make(chan int)
+		go func() {
+			res, err := http.Post(srv.URL, "text/plain", strings.NewReader("foo\n"))
+			if err != nil {
+				done <- 0
+				return
+			}
+			res.Body.Close()
+			done <- res.StatusCode
+		}()
+		<-blocked
+
+		// when
+		res, err := http.Post(srv.URL, "text/plain", strings.NewReader("foo\n"))
+
+		// then
+		assert(t, nil, err)
+		res.Body.Close()
+		assert(t, http.StatusServiceUnavailable, res.StatusCode)
+		assert(t, "1", res.Header.Get("Retry-After"))
+
+		close(release)
+		assert(t, http.StatusOK, <-done)
+		assert(t, uint64(1), h.Stats().Busy)
+	})
+}
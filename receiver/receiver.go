@@ -0,0 +1,253 @@
+/*
+Package receiver provides receivers for log lines a csgo server sends
+to remote addresses.
+
+UDPReceiver handles lines sent by `logaddress_add ip:port`, HTTPHandler
+handles lines posted by `logaddress_add_http url`. Each line received is
+parsed using csgolog and passed to a Handler together with the address
+of the server which sent it.
+*/
+package receiver
+
+import (
+	"sync"
+	"sync/atomic"
+
+	csgolog "github.com/FlowingSPDG/csgo-log"
+)
+
+type (
+
+	// Entry holds a log line received from a server
+	Entry struct {
+		// Server is the address of the server which sent the line
+		Server string
+		// Timestamp is the X-Timestamp header of the request which posted
+		// the line as sent by the server, it is empty for UDP
+		Timestamp string
+		// Raw is the log line as received
+		Raw string
+		// Message is the parsed log line, it is nil if parsing failed
+		Message csgolog.Message
+		// Err is the error returned by parsing the log line, if it wraps
+		// csgolog.ErrorBlockDiscarded the Message is set nevertheless
+		Err error
+	}
+
+	// Handler handles entries received from servers
+	Handler interface {
+		HandleEntry(e Entry)
+	}
+
+	// HandlerFunc is an adapter to use a function as Handler
+	HandlerFunc func(e Entry)
+
+	// Stats holds counters of a receiver
+	Stats struct {
+		// Packets is the number of packets or requests received
+		Packets uint64 `json:"packets"`
+		// Rejected is the number of packets or requests which were rejected
+		// because of an invalid format, secret or token
+		Rejected uint64 `json:"rejected"`
+		// Busy is the number of requests which were refused because
+		// too many requests were in flight
+		Busy uint64 `json:"busy"`
+		// Lines is the number of log lines received, the lines of a
+		// multi-line block are passed to the handler as a single entry
+		Lines uint64 `json:"lines"`
+		// Errors is the number of log lines which could not be parsed
+		// or which discarded an incomplete multi-line block
+		Errors uint64 `json:"errors"`
+	}
+
+	// Option configures a receiver
+	Option func(*config)
+
+	config struct {
+		secret      string
+		token       string
+		maxInFlight int
+		maxBodySize int64
+		registry    *csgolog.Registry
+		newParser   func(server string) *csgolog.Parser
+		parsers     *parsers
+	}
+
+	// parsers holds a parser for each server, the parser used least
+	// recently is dropped if there are more than max servers
+	parsers struct {
+		mu      sync.Mutex
+		max     int
+		seq     uint64
+		servers map[string]*serverParser
+	}
+
+	serverParser struct {
+		parser *csgolog.Parser
+		used   uint64
+	}
+)
+
+// DefaultMaxServers is the maximum number of servers a receiver keeps a parser for
+const DefaultMaxServers = 1024
+
+// HandleEntry calls f(e)
+func (f HandlerFunc) HandleEntry(e Entry) {
+	f(e)
+}
+
+// Chan returns a Handler which sends entries to ch,
+// the receiver blocks if ch is full
+func Chan(ch chan<- Entry) Handler {
+	return HandlerFunc(func(e Entry) {
+		ch <- e
+	})
+}
+
+// WithSecret sets the secret a server sends with each line (sv_logsecret),
+// lines without or with a different secret are rejected
+func WithSecret(secret string) Option {
+	return func(c *config) {
+		c.secret = secret
+	}
+}
+
+// WithToken sets the token a server must send with each request, either
+// as `Authorization: Bearer <token>` header or as `token` query parameter
+func WithToken(token string) Option {
+	return func(c *config) {
+		c.token = token
+	}
+}
+
+// WithMaxInFlight sets the maximum number of requests handled concurrently,
+// further requests are answered with 503 Service Unavailable so servers
+// retry later. By default the number of requests is not limited.
+func WithMaxInFlight(n int) Option {
+	return func(c *config) {
+		c.maxInFlight = n
+	}
+}
+
+// WithMaxBodySize sets the maximum size of a request body,
+// DefaultMaxBodySize is used by default
+func WithMaxBodySize(n int64) Option {
+	return func(c *config) {
+		c.maxBodySize = n
+	}
+}
+
+// WithRegistry sets the registry used for parsing,
+// csgolog.DefaultPatterns is used by default
+func WithRegistry(registry *csgolog.Registry) Option {
+	return func(c *config) {
+		c.registry = registry
+	}
+}
+
+// WithMaxServers sets the maximum number of servers a parser is kept for,
+// DefaultMaxServers is used by default. The server names are sent by the
+// clients, so the limit keeps them from exhausting the memory.
+func WithMaxServers(n int) Option {
+	return func(c *config) {
+		c.parsers.max = n
+	}
+}
+
+// WithParser sets a function returning the parser for the lines of a server,
+// e.g. to set the location of each server. It is called once per server
+// unless the parser of the server was dropped because of WithMaxServers.
+func WithParser(fn func(server string) *csgolog.Parser) Option {
+	return func(c *config) {
+		c.newParser = fn
+	}
+}
+
+func newConfig(opts []Option) config {
+	c := config{
+		registry:    csgolog.DefaultPatterns,
+		maxBodySize: DefaultMaxBodySize,
+		parsers:     &parsers{max: DefaultMaxServers, servers: map[string]*serverParser{}},
+	}
+
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if c.newParser == nil {
+		registry := c.registry
+		c.newParser = func(string) *csgolog.Parser {
+			return csgolog.NewParser(registry)
+		}
+	}
+
+	return c
+}
+
+// parse parses a log line into an entry, it returns false
+// if the line is part of a multi-line block
+func (c *config) parse(server string, line string) (Entry, bool) {
+	m, err := c.parsers.get(server, c.newParser).Parse(line)
+
+	if err == csgolog.ErrorPending {
+		return Entry{}, false
+	}
+
+	return Entry{
+		Server:  server,
+		Raw:     line,
+		Message: m,
+		Err:     err,
+	}, true
+}
+
+// get returns the parser of a server, it is created on first use
+func (p *parsers) get(server string, newParser func(string) *csgolog.Parser) *csgolog.Parser {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.seq++
+
+	s, ok := p.servers[server]
+
+	if !ok {
+		if p.max > 0 && len(p.servers) >= p.max {
+			p.evict()
+		}
+		s = &serverParser{parser: newParser(server)}
+		p.servers[server] = s
+	}
+
+	s.used = p.seq
+
+	return s.parser
+}
+
+// evict drops the parser used least recently
+func (p *parsers) evict() {
+	var (
+		oldest string
+		used   uint64
+	)
+
+	for server, s := range p.servers {
+		if used == 0 || s.used < used {
+			oldest, used = server, s.used
+		}
+	}
+
+	delete(p.servers, oldest)
+}
+
+// counters are updated atomically, use snapshot to read them
+type counters Stats
+
+func (c *counters) snapshot() Stats {
+	return Stats{
+		Packets:  atomic.LoadUint64(&c.Packets),
+		Rejected: atomic.LoadUint64(&c.Rejected),
+		Busy:     atomic.LoadUint64(&c.Busy),
+		Lines:    atomic.LoadUint64(&c.Lines),
+		Errors:   atomic.LoadUint64(&c.Errors),
+	}
+}
@@ -0,0 +1,152 @@
+package receiver
+
+import (
+	"bytes"
+	"net"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	// packetHeader starts each packet sent by a server
+	packetHeader = "\xff\xff\xff\xff"
+	// packetTypeLog is the packet type of a log line without secret
+	packetTypeLog = 'R'
+	// packetTypeSecret is the packet type of a log line with secret
+	packetTypeSecret = 'S'
+	// logLinePrefix starts each log line of a packet
+	logLinePrefix = "L "
+	// maxPacketSize is the maximum size of an UDP packet
+	maxPacketSize = 65535
+)
+
+// UDPReceiver receives log lines sent by servers via `logaddress_add ip:port`
+type UDPReceiver struct {
+	// stats is accessed atomically and must stay 64-bit aligned
+	stats   counters
+	conn    net.PacketConn
+	handler Handler
+	config  config
+	closed  int32
+}
+
+// ListenUDP listens on the UDP address addr, e.g. ":27500",
+// and returns a receiver passing received lines to h
+func ListenUDP(addr string, h Handler, opts ...Option) (*UDPReceiver, error) {
+	conn, err := net.ListenPacket("udp", addr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewUDPReceiver(conn, h, opts...), nil
+}
+
+// NewUDPReceiver returns a receiver reading packets from conn
+// and passing received lines to h
+func NewUDPReceiver(conn net.PacketConn, h Handler, opts ...Option) *UDPReceiver {
+	return &UDPReceiver{
+		conn:    conn,
+		handler: h,
+		config:  newConfig(opts),
+	}
+}
+
+// Serve reads packets until the receiver is closed, the handler is
+// called for every line in the order the packets were received.
+// Serve returns nil after Close was called.
+func (r *UDPReceiver) Serve() error {
+	buf := make([]byte, maxPacketSize)
+
+	for {
+		n, addr, err := r.conn.ReadFrom(buf)
+
+		if err != nil {
+			if atomic.LoadInt32(&r.closed) == 1 {
+				return nil
+			}
+			return err
+		}
+
+		atomic.AddUint64(&r.stats.Packets, 1)
+
+		lines, ok := r.unpack(buf[:n])
+
+		if !ok {
+			atomic.AddUint64(&r.stats.Rejected, 1)
+			continue
+		}
+
+		for _, l := range lines {
+			e, ok := r.config.parse(addr.String(), l)
+
+			atomic.AddUint64(&r.stats.Lines, 1)
+
+			// lines of a multi-line block are handled with its last line
+			if !ok {
+				continue
+			}
+
+			if e.Err != nil {
+				atomic.AddUint64(&r.stats.Errors, 1)
+			}
+
+			r.handler.HandleEntry(e)
+		}
+	}
+}
+
+// Close stops the receiver and closes the underlying connection
+func (r *UDPReceiver) Close() error {
+	atomic.StoreInt32(&r.closed, 1)
+	return r.conn.Close()
+}
+
+// Addr returns the local address the receiver is listening on
+func (r *UDPReceiver) Addr() net.Addr {
+	return r.conn.LocalAddr()
+}
+
+// Stats returns the current counters of the receiver
+func (r *UDPReceiver) Stats() Stats {
+	return r.stats.snapshot()
+}
+
+// unpack strips header and secret of a packet and returns its lines,
+// it reports false if the packet is invalid or the secret does not match
+func (r *UDPReceiver) unpack(packet []byte) ([]string, bool) {
+	if !bytes.HasPrefix(packet, []byte(packetHeader)) || len(packet) <= len(packetHeader) {
+		return nil, false
+	}
+
+	payload := packet[len(packetHeader)+1:]
+
+	switch packet[len(packetHeader)] {
+	case packetTypeLog:
+		if r.config.secret != "" {
+			return nil, false
+		}
+	case packetTypeSecret:
+		if r.config.secret == "" || !bytes.HasPrefix(payload, []byte(r.config.secret)) {
+			return nil, false
+		}
+		payload = payload[len(r.config.secret):]
+
+		// the log line must follow the secret immediately, otherwise
+		// a longer secret starting with the configured one would pass
+		if !bytes.HasPrefix(payload, []byte(logLinePrefix)) {
+			return nil, false
+		}
+	default:
+		return nil, false
+	}
+
+	// lines are terminated by a newline and a null byte
+	text := strings.TrimRight(string(payload), "\x00\r\n")
+
+	if text == "" {
+		return nil, false
+	}
+
+	return strings.Split(text, "\n"), true
+}
@@ -0,0 +1,151 @@
+package receiver
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	csgolog "github.com/FlowingSPDG/csgo-log"
+)
+
+func TestUDPReceiver(t *testing.T) {
+
+	t.Run("without secret", func(t *testing.T) {
+
+		// given
+		entries := make(chan Entry, 10)
+		r, conn := listen(t, entries)
+		defer r.Close()
+		defer conn.Close()
+
+		// when
+		send(t, conn, "\xff\xff\xff\xffRL 11/05/2018 - 15:44:36: \"Player-Name<12><STEAM_1:1:0101011><CT>\" purchased \"m4a1\"\n\x00")
+		e := receive(t, entries)
+
+		// then
+		assert(t, nil, e.Err)
+		assert(t, conn.LocalAddr().String(), e.Server)
+		assert(t, `L 11/05/2018 - 15:44:36: "Player-Name<12><STEAM_1:1:0101011><CT>" purchased "m4a1"`, e.Raw)
+		assert(t, "PlayerPurchase", e.Message.GetType())
+		assert(t, "m4a1", e.Message.(csgolog.PlayerPurchase).Item)
+	})
+
+	t.Run("with secret", func(t *testing.T) {
+
+		// given
+		entries := make(chan Entry, 10)
+		r, conn := listen(t, entries, WithSecret("s3cret"))
+		defer r.Close()
+		defer conn.Close()
+
+		// when
+		send(t, conn, "\xff\xff\xff\xffRL 11/05/2018 - 15:44:36: World triggered \"Round_Start\"\n\x00")
+		send(t, conn, "\xff\xff\xff\xffSwrongL 11/05/2018 - 15:44:36: World triggered \"Round_End\"\n\x00")
+		send(t, conn, "\xff\xff\xff\xffSs3cret9L 11/05/2018 - 15:44:36: World triggered \"Round_End\"\n\x00")
+		send(t, conn, "\xff\xff\xff\xffSs3cretL 11/05/2018 - 15:44:36: Starting Freeze period\n\x00")
+		e := receive(t, entries)
+
+		// then
+		assert(t, nil, e.Err)
+		assert(t, "FreezTimeStart", e.Message.GetType())
+		assert(t, Stats{Packets: 4, Rejected: 3, Lines: 1}, r.Stats())
+	})
+
+	t.Run("invalid packets", func(t *testing.T) {
+
+		// given
+		entries := make(chan Entry, 10)
+		r, conn := listen(t, entries)
+		defer r.Close()
+		defer conn.Close()
+
+		// when
+		send(t, conn, "L 11/05/2018 - 15:44:36: World triggered \"Round_Start\"\n")
+		send(t, conn, "\xff\xff\xff\xffX")
+		send(t, conn, "\xff\xff\xff\xffRfoo\n\x00")
+		e := receive(t, entries)
+
+		// then
+		assert(t, true, errors.Is(e.Err, csgolog.ErrorNoMatch))
+		assert(t, nil, e.Message)
+		assert(t, "foo", e.Raw)
+		assert(t, Stats{Packets: 3, Rejected: 2, Lines: 1, Errors: 1}, r.Stats())
+	})
+
+	t.Run("close", func(t *testing.T) {
+
+		// given
+		r, err := ListenUDP("127.0.0.1:0", HandlerFunc(func(Entry) {}))
+		assert(t, nil, err)
+
+		done := make(chan error)
+		go func() {
+			done <- r.Serve()
+		}()
+
+		// when
+		r.Close()
+
+		// then
+		select {
+		case err := <-done:
+			assert(t, nil, err)
+		case <-time.After(time.Second):
+			t.Fatal("Serve did not return after Close")
+		}
+	})
+}
+
+// helper
+
+func listen(t *testing.T, entries chan Entry, opts ...Option) (*UDPReceiver, net.Conn) {
+	t.Helper()
+
+	r, err := ListenUDP("127.0.0.1:0", Chan(entries), opts...)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	go r.Serve()
+
+	conn, err := net.Dial("udp", r.Addr().String())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r, conn
+}
+
+func send(t *testing.T, conn net.Conn, packet string) {
+	t.Helper()
+
+	if _, err := conn.Write([]byte(packet)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func receive(t *testing.T, entries chan Entry) Entry {
+	t.Helper()
+
+	select {
+	case e := <-entries:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("no entry received")
+	}
+
+	return Entry{}
+}
+
+func assert(t *testing.T, want interface{}, have interface{}) {
+
+	// mark as test helper function
+	t.Helper()
+
+	if want != have {
+		t.Error("Assertion failed for", t.Name(), "\n\twanted:\t", want, "\n\thave:\t", have)
+	}
+}
@@ -0,0 +1,356 @@
+package csgolog
+
+import (
+	"errors"
+	"regexp"
+	"regexp/syntax"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrorDuplicatePattern error when a pattern name is already registered
+var ErrorDuplicatePattern = errors.New("duplicate pattern")
+
+// Pattern is a named entry of a Registry. Patterns with a higher
+// Priority are tried first, patterns with equal priority are tried
+// in the order they were added.
+type Pattern struct {
+	// Name is the type of the messages created by the pattern,
+	// custom types are usually registered with RegisterType too
+	Name     MessageType
+	Priority int
+	Regexp   *regexp.Regexp
+	Func     MessageFunc
+	// Check is called in strict mode before Func if set, it returns
+	// a *ConversionError for numbers of the match which the registry
+	// can't derive from Regexp, e.g. numbers within a list
+	Check func(r []string) error
+}
+
+// NewPattern compiles expr and returns a Pattern with default priority,
+// it panics if expr is not a valid regular expression
+func NewPattern(name MessageType, expr string, fn MessageFunc) Pattern {
+	return Pattern{
+		Name:   name,
+		Regexp: regexp.MustCompile(expr),
+		Func:   fn,
+	}
+}
+
+// Registry holds an ordered set of patterns. Unlike a map of patterns
+// the order in which patterns are tried is deterministic, so the same
+// line always results in the same message type.
+//
+// For each pattern the registry derives the longest literal text every
+// match must contain (e.g. `" purchased "`), a pattern is only tried if
+// this keyword is part of the message body. Most lines are therefore
+// matched against a few regular expressions only.
+//
+// A Registry is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	entries []entry
+	seq     int
+}
+
+type entry struct {
+	Pattern
+	seq     int
+	keyword string
+	numbers []number
+}
+
+// number is a submatch of a pattern holding a number
+type number struct {
+	index int
+	float bool
+}
+
+// NewRegistry returns a Registry holding the given patterns,
+// it panics if two patterns share the same name
+func NewRegistry(patterns ...Pattern) *Registry {
+	r := &Registry{}
+	for _, p := range patterns {
+		if err := r.Add(p); err != nil {
+			panic(err.Error() + ": " + string(p.Name))
+		}
+	}
+	return r
+}
+
+// Add adds a pattern to the registry, it returns ErrorDuplicatePattern
+// if a pattern with the same name is already registered
+func (r *Registry) Add(p Pattern) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.index(p.Name) >= 0 {
+		return ErrorDuplicatePattern
+	}
+
+	r.entries = append(r.entries, newEntry(p, r.seq))
+	r.seq++
+	r.sort()
+
+	return nil
+}
+
+// Set adds a pattern or overrides the pattern with the same name,
+// an overridden pattern keeps its position among patterns of equal priority
+func (r *Registry) Set(p Pattern) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if i := r.index(p.Name); i >= 0 {
+		r.entries[i] = newEntry(p, r.entries[i].seq)
+	} else {
+		r.entries = append(r.entries, newEntry(p, r.seq))
+		r.seq++
+	}
+	r.sort()
+}
+
+// Remove removes the pattern with the given name and
+// reports whether it was registered
+func (r *Registry) Remove(name MessageType) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.index(name)
+	if i < 0 {
+		return false
+	}
+	r.entries = append(r.entries[:i:i], r.entries[i+1:]...)
+
+	return true
+}
+
+// Lookup returns the pattern with the given name
+func (r *Registry) Lookup(name MessageType) (Pattern, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if i := r.index(name); i >= 0 {
+		return r.entries[i].Pattern, true
+	}
+	return Pattern{}, false
+}
+
+// Patterns returns the registered patterns in the order they are tried
+func (r *Registry) Patterns() []Pattern {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	patterns := make([]Pattern, len(r.entries))
+	for i, e := range r.entries {
+		patterns[i] = e.Pattern
+	}
+	return patterns
+}
+
+// Clone returns a copy of the registry which can be modified
+// without affecting the original
+func (r *Registry) Clone() *Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return &Registry{
+		entries: append([]entry(nil), r.entries...),
+		seq:     r.seq,
+	}
+}
+
+// match returns the message of the first pattern matching the message body,
+// in strict mode an error is returned if a number of the match can't be converted
+func (r *Registry) match(ti time.Time, body string, strict bool) (Message, bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, e := range r.entries {
+		// skip the regular expression if it can't match the body
+		if !strings.Contains(body, e.keyword) {
+			continue
+		}
+
+		result := e.Regexp.FindStringSubmatch(body)
+
+		if result == nil {
+			continue
+		}
+
+		if !strict {
+			return e.Func(ti, result), true, nil
+		}
+
+		if err := e.convert(result); err != nil {
+			return nil, false, err
+		}
+
+		if e.Check != nil {
+			if err := e.Check(result); err != nil {
+				return nil, false, err
+			}
+		}
+
+		m := e.Func(ti, result)
+
+		// a MessageFunc returns Unknown if it fails to convert the match
+		if TypeUnknown.Is(m) {
+			return nil, false, &ConversionError{Pattern: string(e.Name), Err: ErrorConversion}
+		}
+
+		return m, true, nil
+	}
+	return nil, false, nil
+}
+
+// convert checks that all numbers of a match can be converted
+func (e *entry) convert(result []string) error {
+	for _, n := range e.numbers {
+		v := result[n.index]
+
+		// optional submatches are empty if not matched
+		if v == "" {
+			continue
+		}
+
+		var err error
+		if n.float {
+			_, err = strconv.ParseFloat(v, 32)
+		} else {
+			_, err = strconv.Atoi(v)
+		}
+
+		if err != nil {
+			return &ConversionError{Pattern: string(e.Name), Index: n.index, Value: v, Err: err}
+		}
+	}
+	return nil
+}
+
+func (r *Registry) index(name MessageType) int {
+	for i, e := range r.entries {
+		if e.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *Registry) sort() {
+	sort.SliceStable(r.entries, func(i, j int) bool {
+		if r.entries[i].Priority != r.entries[j].Priority {
+			return r.entries[i].Priority > r.entries[j].Priority
+		}
+		return r.entries[i].seq < r.entries[j].seq
+	})
+}
+
+func newEntry(p Pattern, seq int) entry {
+	e := entry{
+		Pattern: p,
+		seq:     seq,
+	}
+
+	tree, err := syntax.Parse(p.Regexp.String(), syntax.Perl)
+
+	if err == nil {
+		tree = tree.Simplify()
+		e.keyword = literal(tree)
+		e.numbers = numbers(tree, nil)
+	}
+
+	return e
+}
+
+// literal returns the longest literal every match of re contains,
+// an empty string is returned if there is no such literal
+func literal(re *syntax.Regexp) string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase != 0 {
+			return ""
+		}
+		return string(re.Rune)
+	case syntax.OpCapture, syntax.OpPlus:
+		return literal(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min > 0 {
+			return literal(re.Sub[0])
+		}
+	case syntax.OpConcat:
+		longest := ""
+		for _, sub := range re.Sub {
+			if l := literal(sub); len(l) > len(longest) {
+				longest = l
+			}
+		}
+		return longest
+	}
+	return ""
+}
+
+// numbers returns the submatches of re which hold a number
+func numbers(re *syntax.Regexp, found []number) []number {
+	if re.Op == syntax.OpCapture {
+		if ok, digit, point := numeric(re.Sub[0]); ok && digit {
+			found = append(found, number{index: re.Cap, float: point})
+		}
+	}
+	for _, sub := range re.Sub {
+		found = numbers(sub, found)
+	}
+	return found
+}
+
+// numeric reports whether re matches digits, signs and decimal points only,
+// whether it contains digits and whether it contains a decimal point
+func numeric(re *syntax.Regexp) (ok bool, digit bool, point bool) {
+	switch re.Op {
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if ok, d, p := numericRange(r, r); ok {
+				digit, point = digit || d, point || p
+			} else {
+				return false, false, false
+			}
+		}
+		return true, digit, point
+	case syntax.OpCharClass:
+		for i := 0; i < len(re.Rune); i += 2 {
+			if ok, d, p := numericRange(re.Rune[i], re.Rune[i+1]); ok {
+				digit, point = digit || d, point || p
+			} else {
+				return false, false, false
+			}
+		}
+		return true, digit, point
+	case syntax.OpCapture, syntax.OpPlus, syntax.OpStar, syntax.OpQuest, syntax.OpRepeat:
+		return numeric(re.Sub[0])
+	case syntax.OpConcat:
+		for _, sub := range re.Sub {
+			if ok, d, p := numeric(sub); ok {
+				digit, point = digit || d, point || p
+			} else {
+				return false, false, false
+			}
+		}
+		return true, digit, point
+	}
+	return false, false, false
+}
+
+func numericRange(lo, hi rune) (ok bool, digit bool, point bool) {
+	switch {
+	case lo >= '0' && hi <= '9':
+		return true, true, false
+	case lo == '.' && hi == '.':
+		return true, false, true
+	case lo == hi && (lo == '-' || lo == '+'):
+		return true, false, false
+	}
+	return false, false, false
+}
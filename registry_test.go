@@ -0,0 +1,190 @@
+package csgolog
+
+import (
+	"io/ioutil"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRegistry(t *testing.T) {
+
+	triggered := func(ti time.Time, r []string) Message {
+		return Unknown{Meta: NewMeta(ti, "Triggered"), Raw: r[1]}
+	}
+
+	t.Run("default patterns", func(t *testing.T) {
+
+		// when
+		m, err := ParseWith(line(`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Got_The_Bomb"`), DefaultPatterns)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerBombGot", m.GetType())
+	})
+
+	t.Run("nil registry", func(t *testing.T) {
+
+		// when
+		m, err := ParseWith(line(`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Got_The_Bomb"`), nil)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "PlayerBombGot", m.GetType())
+	})
+
+	t.Run("order is deterministic", func(t *testing.T) {
+
+		// given
+		r := DefaultPatterns.Clone()
+		err := r.Add(NewPattern("Triggered", `triggered "(\w+)"`, triggered))
+
+		// then
+		assert(t, nil, err)
+
+		for i := 0; i < 100; i++ {
+
+			// when
+			m, _ := ParseWith(line(`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Got_The_Bomb"`), r)
+
+			// then
+			assert(t, "PlayerBombGot", m.GetType())
+		}
+	})
+
+	t.Run("priority", func(t *testing.T) {
+
+		// given
+		r := DefaultPatterns.Clone()
+		p := NewPattern("Triggered", `triggered "(\w+)"`, triggered)
+		p.Priority = 1
+		r.Add(p)
+
+		// when
+		m, err := ParseWith(line(`"Player-Name<2><STEAM_1:1:0101011><TERRORIST>" triggered "Got_The_Bomb"`), r)
+
+		// then
+		assert(t, nil, err)
+		assert(t, "Triggered", m.GetType())
+		assert(t, "Got_The_Bomb", m.(Unknown).Raw)
+		assert(t, MessageType("Triggered"), r.Patterns()[0].Name)
+	})
+
+	t.Run("add duplicate", func(t *testing.T) {
+
+		// given
+		r := DefaultPatterns.Clone()
+
+		// when
+		err := r.Add(NewPattern(TypePlayerBombGot, `triggered "(\w+)"`, triggered))
+
+		// then
+		assert(t, ErrorDuplicatePattern, err)
+	})
+
+	t.Run("set overrides in place", func(t *testing.T) {
+
+		// given
+		r := NewRegistry(
+			NewPattern("A", `a`, triggered),
+			NewPattern("B", `b`, triggered),
+			NewPattern("C", `c`, triggered),
+		)
+
+		// when
+		r.Set(NewPattern("B", `(bb)`, triggered))
+
+		// then
+		p, ok := r.Lookup("B")
+		assert(t, true, ok)
+		assert(t, "(bb)", p.Regexp.String())
+		assert(t, MessageType("B"), r.Patterns()[1].Name)
+		assert(t, 3, len(r.Patterns()))
+	})
+
+	t.Run("remove", func(t *testing.T) {
+
+		// given
+		r := DefaultPatterns.Clone()
+
+		// when
+		removed := r.Remove("PlayerPurchase")
+		m, err := ParseWith(line(`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" purchased "m4a1"`), r)
+
+		// then
+		assert(t, true, removed)
+		assert(t, false, r.Remove("PlayerPurchase"))
+		assert(t, nil, err)
+		assert(t, "Unknown", m.GetType())
+
+		_, ok := DefaultPatterns.Lookup("PlayerPurchase")
+		assert(t, true, ok)
+	})
+}
+
+func TestEntryKeyword(t *testing.T) {
+
+	keywords := map[string]string{
+		PlayerPurchasePattern:    `" purchased "`,
+		PlayerAttackPattern:      `") (damage_armor "`,
+		WorldRoundStartPattern:   `World triggered "Round_Start"`,
+		PlayerMoneyChangePattern: `" money change `,
+		Get5EventPattern:         `get5_event: {"matchid`,
+		`(?i)world triggered`:    ``,
+		`(foo|bar)`:              ``,
+		`(foo)?bar`:              `bar`,
+	}
+
+	for expr, want := range keywords {
+
+		// when
+		have := newEntry(Pattern{Regexp: regexp.MustCompile(expr)}, 0).keyword
+
+		// then
+		assert(t, want, have)
+	}
+}
+
+func BenchmarkExampleLog(b *testing.B) {
+
+	lines := exampleLines(b)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, l := range lines {
+			Parse(l)
+		}
+	}
+}
+
+func BenchmarkExampleLogWithPatterns(b *testing.B) {
+
+	lines := exampleLines(b)
+
+	patterns := map[*regexp.Regexp]MessageFunc{}
+	for _, p := range DefaultPatterns.Patterns() {
+		patterns[p.Regexp] = p.Func
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, l := range lines {
+			ParseWithPatterns(l, patterns)
+		}
+	}
+}
+
+func exampleLines(b testing.TB) []string {
+	b.Helper()
+
+	data, err := ioutil.ReadFile("example/example.log")
+
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
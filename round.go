@@ -0,0 +1,54 @@
+package csgolog
+
+import (
+	"strings"
+)
+
+// RoundEndReason is the reason why a round ended
+type RoundEndReason string
+
+// reasons of the end of a round
+const (
+	RoundEndTargetBombed       RoundEndReason = "target_bombed"
+	RoundEndBombDefused        RoundEndReason = "bomb_defused"
+	RoundEndTargetSaved        RoundEndReason = "target_saved"
+	RoundEndElimination        RoundEndReason = "elimination"
+	RoundEndHostagesRescued    RoundEndReason = "hostages_rescued"
+	RoundEndHostagesNotRescued RoundEndReason = "hostages_not_rescued"
+	RoundEndSurrender          RoundEndReason = "surrender"
+	RoundEndDraw               RoundEndReason = "draw"
+	RoundEndUnknown            RoundEndReason = "unknown"
+)
+
+// Reason returns the reason why the round ended
+func (m TeamNotice) Reason() RoundEndReason {
+	return noticeReason(m.Notice)
+}
+
+// Reason returns the reason why the round ended
+func (m WorldNotice) Reason() RoundEndReason {
+	return noticeReason(m.Notice)
+}
+
+// noticeReason returns the reason of a round end notice
+func noticeReason(notice string) RoundEndReason {
+	switch strings.TrimPrefix(notice, "SFUI_Notice_") {
+	case "Target_Bombed":
+		return RoundEndTargetBombed
+	case "Bomb_Defused":
+		return RoundEndBombDefused
+	case "Target_Saved":
+		return RoundEndTargetSaved
+	case "CTs_Win", "Terrorists_Win":
+		return RoundEndElimination
+	case "Hostages_Rescued", "All_Hostages_Rescued":
+		return RoundEndHostagesRescued
+	case "Hostages_Not_Rescued":
+		return RoundEndHostagesNotRescued
+	case "Terrorists_Surrender", "CTs_Surrender":
+		return RoundEndSurrender
+	case "Round_Draw":
+		return RoundEndDraw
+	}
+	return RoundEndUnknown
+}
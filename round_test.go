@@ -0,0 +1,45 @@
+package csgolog
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRoundEndReason(t *testing.T) {
+
+	notices := map[string]RoundEndReason{
+		NoticeTargetBombed:        RoundEndTargetBombed,
+		"Target_Bombed":           RoundEndTargetBombed,
+		NoticeBombDefused:         RoundEndBombDefused,
+		NoticeTargetSaved:         RoundEndTargetSaved,
+		NoticeCTsWin:              RoundEndElimination,
+		NoticeTerroristsWin:       RoundEndElimination,
+		NoticeHostagesRescued:     RoundEndHostagesRescued,
+		NoticeAllHostagesRescued:  RoundEndHostagesRescued,
+		NoticeHostagesNotRescued:  RoundEndHostagesNotRescued,
+		NoticeTerroristsSurrender: RoundEndSurrender,
+		NoticeRoundDraw:           RoundEndDraw,
+		"SFUI_Notice_Something":   RoundEndUnknown,
+	}
+
+	for notice, reason := range notices {
+
+		// when
+		m, err := Parse(line(`Team "TERRORIST" triggered "` + notice + `" (CT "0") (T "1")`))
+
+		// then
+		assert(t, nil, err)
+		assert(t, reason, m.(TeamNotice).Reason())
+
+		if !strings.HasPrefix(notice, "SFUI_Notice_") {
+			continue
+		}
+
+		// when
+		m, err = Parse(line(`World triggered "` + notice + `"`))
+
+		// then
+		assert(t, nil, err)
+		assert(t, reason, m.(WorldNotice).Reason())
+	}
+}
@@ -0,0 +1,248 @@
+package csgolog
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+)
+
+// DefaultMaxLineSize is the maximum length of a line read by a Scanner
+const DefaultMaxLineSize = 1024 * 1024
+
+// ErrorLineTooLong error when a line exceeds the maximum line size of a Scanner
+var ErrorLineTooLong = errors.New("line too long")
+
+// utf8BOM is the byte order mark some editors put at the beginning of a file
+const utf8BOM = "\xef\xbb\xbf"
+
+// Scanner reads a logfile line by line and parses each line into a Message.
+// Successive calls to Scan step through the lines of the logfile, empty lines
+// are skipped. Lines may end with "\n" or "\r\n".
+//
+// By default scanning stops at the first line which can't be parsed,
+// use SkipErrors or CollectErrors to continue with the next line instead.
+type Scanner struct {
+	r       *bufio.Reader
+	parser  *Parser
+	maxLine int
+	skip    bool
+	collect bool
+
+	line  int
+	text  string
+	frame Frame
+	msg   Message
+	err   error
+	errs  []error
+}
+
+// ScannerOption configures a Scanner
+type ScannerOption func(*Scanner)
+
+// WithRegistry sets the registry used for parsing, DefaultPatterns is used by default
+func WithRegistry(registry *Registry) ScannerOption {
+	return func(s *Scanner) {
+		s.parser = NewParser(registry)
+	}
+}
+
+// WithParser sets the parser used for parsing lines
+func WithParser(p *Parser) ScannerOption {
+	return func(s *Scanner) {
+		s.parser = p
+	}
+}
+
+// WithMaxLineSize sets the maximum length of a line, longer lines
+// are reported as ErrorLineTooLong with the first n bytes of the line.
+// DefaultMaxLineSize is used if n is not positive.
+func WithMaxLineSize(n int) ScannerOption {
+	return func(s *Scanner) {
+		if n <= 0 {
+			n = DefaultMaxLineSize
+		}
+		s.maxLine = n
+	}
+}
+
+// SkipErrors makes the Scanner ignore lines which can't be parsed
+func SkipErrors() ScannerOption {
+	return func(s *Scanner) {
+		s.skip = true
+	}
+}
+
+// CollectErrors makes the Scanner continue on lines which can't be parsed,
+// the errors are available from Errors. Errors of multi-line blocks which
+// were discarded are collected as well.
+func CollectErrors() ScannerOption {
+	return func(s *Scanner) {
+		s.skip = true
+		s.collect = true
+	}
+}
+
+// NewScanner returns a Scanner reading from r
+func NewScanner(r io.Reader, opts ...ScannerOption) *Scanner {
+	s := &Scanner{
+		r:       bufio.NewReader(r),
+		parser:  NewParser(DefaultPatterns),
+		maxLine: DefaultMaxLineSize,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// Scan advances to the next message, it returns false when the end
+// of the input is reached or an error occurred
+func (s *Scanner) Scan() bool {
+	if s.err != nil {
+		return false
+	}
+
+	for {
+		text, err := s.readLine()
+
+		if err == io.EOF {
+			s.msg = nil
+			return false
+		}
+
+		// errors of the underlying reader always stop scanning
+		if err != nil && err != ErrorLineTooLong {
+			s.err = err
+			return false
+		}
+
+		s.line++
+		s.text = text
+		s.frame = Frame{}
+		s.msg = nil
+
+		if err == ErrorLineTooLong {
+			err = &ParseError{Line: text, Stage: StageFraming, Err: err}
+		} else {
+			if text == "" {
+				continue
+			}
+			if s.frame, s.msg, err = s.parser.parseLine(text); err == nil {
+				return true
+			}
+			if err == ErrorPending {
+				continue
+			}
+			// the line was parsed but a block before it was discarded
+			if s.msg != nil {
+				s.discarded(err)
+				return true
+			}
+		}
+
+		if pe, ok := err.(*ParseError); ok {
+			pe.LineNumber = s.line
+		}
+
+		if !s.skip {
+			s.err = err
+			return false
+		}
+
+		if s.collect {
+			s.errs = append(s.errs, err)
+		}
+	}
+}
+
+// discarded adds the error of a discarded block to the collected errors
+func (s *Scanner) discarded(err error) {
+	if pe, ok := err.(*ParseError); ok {
+		pe.LineNumber = s.line
+	}
+
+	if s.collect {
+		s.errs = append(s.errs, err)
+	}
+}
+
+// Message returns the message parsed by the last call to Scan
+func (s *Scanner) Message() Message {
+	return s.msg
+}
+
+// Line returns the line number of the last line read by Scan, starting at 1
+func (s *Scanner) Line() int {
+	return s.line
+}
+
+// Text returns the raw text of the last line read by Scan
+// without the line ending, lines which are too long are
+// truncated to the maximum line size
+func (s *Scanner) Text() string {
+	return s.text
+}
+
+// Framing returns the framing detected for the last line read by Scan
+func (s *Scanner) Framing() Framing {
+	return s.frame.Framing
+}
+
+// Err returns the error which stopped scanning, errors of lines
+// which can't be parsed are of type *ParseError
+func (s *Scanner) Err() error {
+	return s.err
+}
+
+// Errors returns the errors of all lines skipped when using CollectErrors,
+// each error is of type *ParseError
+func (s *Scanner) Errors() []error {
+	return s.errs
+}
+
+// readLine reads a single line and strips the line ending, the
+// byte order mark is removed from the first line
+func (s *Scanner) readLine() (string, error) {
+	var b strings.Builder
+	tooLong := false
+
+	for {
+		fragment, isPrefix, err := s.r.ReadLine()
+
+		if err != nil {
+			if err == io.EOF && (b.Len() > 0 || tooLong) {
+				break
+			}
+			return "", err
+		}
+
+		// keep the beginning of a line which is too long
+		if n := s.maxLine - b.Len(); len(fragment) > n {
+			fragment = fragment[:n]
+			tooLong = true
+		}
+
+		b.Write(fragment)
+
+		if !isPrefix {
+			break
+		}
+	}
+
+	text := b.String()
+
+	if tooLong {
+		return text, ErrorLineTooLong
+	}
+
+	text = strings.TrimSuffix(text, "\r")
+
+	if s.line == 0 {
+		text = strings.TrimPrefix(text, utf8BOM)
+	}
+
+	return text, nil
+}
@@ -0,0 +1,193 @@
+package csgolog
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestScanner(t *testing.T) {
+
+	t.Run("example log", func(t *testing.T) {
+
+		// given
+		f, err := os.Open("example/example.log")
+		assert(t, nil, err)
+		defer f.Close()
+
+		s := NewScanner(f)
+		n := 0
+
+		// when
+		for s.Scan() {
+			n++
+			assert(t, n, s.Line())
+		}
+
+		// then
+		assert(t, nil, s.Err())
+		assert(t, 2894, n)
+		assert(t, "L 11/12/2018 - 20:19:06: Log file closed", s.Text())
+	})
+
+	t.Run("line number and text", func(t *testing.T) {
+
+		// given
+		s := NewScanner(strings.NewReader(line(`World triggered "Round_Start"`) + "\n" + line(`World triggered "Round_End"`)))
+
+		// when
+		ok := s.Scan()
+
+		// then
+		assert(t, true, ok)
+		assert(t, 1, s.Line())
+		assert(t, "WorldRoundStart", s.Message().GetType())
+
+		// when
+		ok = s.Scan()
+
+		// then
+		assert(t, true, ok)
+		assert(t, 3, s.Line())
+		assert(t, "WorldRoundEnd", s.Message().GetType())
+		assert(t, `L 11/05/2018 - 15:44:36: World triggered "Round_End"`, s.Text())
+
+		// when
+		ok = s.Scan()
+
+		// then
+		assert(t, false, ok)
+		assert(t, nil, s.Err())
+		assert(t, nil, s.Message())
+	})
+
+	t.Run("CRLF and BOM", func(t *testing.T) {
+
+		// given
+		input := "\xef\xbb\xbfL 11/05/2018 - 15:44:36: server_message: \"quit\"\r\nL 11/05/2018 - 15:44:36: Starting Freeze period\r\n"
+		s := NewScanner(strings.NewReader(input))
+
+		// when
+		s.Scan()
+
+		// then
+		assert(t, "ServerMessage", s.Message().GetType())
+		assert(t, "quit", s.Message().(ServerMessage).Text)
+
+		// when
+		s.Scan()
+
+		// then
+		assert(t, "FreezTimeStart", s.Message().GetType())
+		assert(t, `L 11/05/2018 - 15:44:36: Starting Freeze period`, s.Text())
+		assert(t, false, s.Scan())
+		assert(t, nil, s.Err())
+	})
+
+	t.Run("long line", func(t *testing.T) {
+
+		// given
+		text := strings.Repeat("a", 100000)
+		s := NewScanner(strings.NewReader(line(`"Player-Name<12><STEAM_1:1:0101011><TERRORIST>" say "` + text + `"`)))
+
+		// when
+		ok := s.Scan()
+
+		// then
+		assert(t, true, ok)
+		assert(t, text, s.Message().(PlayerSay).Text)
+	})
+
+	t.Run("line too long", func(t *testing.T) {
+
+		// given
+		input := line(strings.Repeat("a", 10000)) + line(`Starting Freeze period`)
+		s := NewScanner(strings.NewReader(input), WithMaxLineSize(5000))
+
+		// when
+		ok := s.Scan()
+
+		// then
+		assert(t, false, ok)
+		assert(t, true, errors.Is(s.Err(), ErrorLineTooLong))
+		assert(t, 1, s.Line())
+		assert(t, "L 11/05/2018 - 15:44:36: aaa", s.Text()[:28])
+		assert(t, 5000, len(s.Text()))
+
+		var pe *ParseError
+		assert(t, true, errors.As(s.Err(), &pe))
+		assert(t, s.Text(), pe.Line)
+	})
+
+	t.Run("invalid max line size", func(t *testing.T) {
+
+		for _, n := range []int{0, -1} {
+
+			// given
+			s := NewScanner(strings.NewReader(line(`Starting Freeze period`)), WithMaxLineSize(n))
+
+			// when
+			ok := s.Scan()
+
+			// then
+			assert(t, true, ok)
+			assert(t, nil, s.Err())
+			assert(t, "FreezTimeStart", s.Message().GetType())
+		}
+	})
+
+	t.Run("stop on error", func(t *testing.T) {
+
+		// given
+		s := NewScanner(strings.NewReader("foo\n" + line(`Starting Freeze period`)))
+
+		// when
+		ok := s.Scan()
+
+		// then
+		assert(t, false, ok)
+		assert(t, true, errors.Is(s.Err(), ErrorNoMatch))
+		assert(t, "line 1: framing: no match", s.Err().Error())
+		assert(t, 1, s.Err().(*ParseError).LineNumber)
+		assert(t, "foo", s.Err().(*ParseError).Line)
+		assert(t, "foo", s.Text())
+		assert(t, false, s.Scan())
+	})
+
+	t.Run("skip errors", func(t *testing.T) {
+
+		// given
+		s := NewScanner(strings.NewReader("foo\n"+line(`Starting Freeze period`)), SkipErrors())
+
+		// when
+		ok := s.Scan()
+
+		// then
+		assert(t, true, ok)
+		assert(t, 2, s.Line())
+		assert(t, "FreezTimeStart", s.Message().GetType())
+		assert(t, 0, len(s.Errors()))
+	})
+
+	t.Run("collect errors", func(t *testing.T) {
+
+		// given
+		input := "foo\n" + line(`Starting Freeze period`) + line(strings.Repeat("a", 10000)) + "bar\n"
+		s := NewScanner(strings.NewReader(input), CollectErrors(), WithMaxLineSize(5000))
+		n := 0
+
+		// when
+		for s.Scan() {
+			n++
+		}
+
+		// then
+		assert(t, 1, n)
+		assert(t, nil, s.Err())
+		assert(t, 3, len(s.Errors()))
+		assert(t, "line 1: framing: no match", s.Errors()[0].Error())
+		assert(t, true, errors.Is(s.Errors()[1], ErrorLineTooLong))
+		assert(t, "line 4: framing: no match", s.Errors()[2].Error())
+	})
+}
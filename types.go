@@ -0,0 +1,133 @@
+package csgolog
+
+import (
+	"sort"
+)
+
+// MessageType is the type of a message as stored in Meta.Type
+type MessageType string
+
+// message types of the messages of this package
+const (
+	TypeServerMessage         MessageType = "ServerMessage"
+	TypeFreezTimeStart        MessageType = "FreezTimeStart"
+	TypeWorldMatchStart       MessageType = "WorldMatchStart"
+	TypeWorldRoundStart       MessageType = "WorldRoundStart"
+	TypeWorldRoundRestart     MessageType = "WorldRoundRestart"
+	TypeWorldRoundEnd         MessageType = "WorldRoundEnd"
+	TypeWorldGameCommencing   MessageType = "WorldGameCommencing"
+	TypeTeamScored            MessageType = "TeamScored"
+	TypeTeamNotice            MessageType = "TeamNotice"
+	TypePlayerConnected       MessageType = "PlayerConnected"
+	TypePlayerDisconnected    MessageType = "PlayerDisconnected"
+	TypePlayerEntered         MessageType = "PlayerEntered"
+	TypePlayerBanned          MessageType = "PlayerBanned"
+	TypePlayerSwitched        MessageType = "PlayerSwitched"
+	TypePlayerSay             MessageType = "PlayerSay"
+	TypePlayerPurchase        MessageType = "PlayerPurchase"
+	TypePlayerKill            MessageType = "PlayerKill"
+	TypePlayerKillAssist      MessageType = "PlayerKillAssist"
+	TypePlayerAttack          MessageType = "PlayerAttack"
+	TypePlayerKilledBomb      MessageType = "PlayerKilledBomb"
+	TypePlayerKilledSuicide   MessageType = "PlayerKilledSuicide"
+	TypePlayerPickedUp        MessageType = "PlayerPickedUp"
+	TypePlayerDropped         MessageType = "PlayerDropped"
+	TypePlayerMoneyChange     MessageType = "PlayerMoneyChange"
+	TypePlayerBombGot         MessageType = "PlayerBombGot"
+	TypePlayerBombPlanted     MessageType = "PlayerBombPlanted"
+	TypePlayerBombDropped     MessageType = "PlayerBombDropped"
+	TypePlayerBombBeginDefuse MessageType = "PlayerBombBeginDefuse"
+	TypePlayerBombDefused     MessageType = "PlayerBombDefused"
+	TypePlayerThrew           MessageType = "PlayerThrew"
+	TypePlayerBlinded         MessageType = "PlayerBlinded"
+	TypeProjectileSpawned     MessageType = "ProjectileSpawned"
+	TypeGameOver              MessageType = "GameOver"
+	TypeServerCvar            MessageType = "ServerCvar"
+	TypeGet5Event             MessageType = "Get5Event"
+	TypeRcon                  MessageType = "Rcon"
+	TypePlayerKillOther       MessageType = "PlayerKillOther"
+	TypeVoteStarted           MessageType = "VoteStarted"
+	TypeVoteCast              MessageType = "VoteCast"
+	TypeVoteResult            MessageType = "VoteResult"
+	TypeMatchPauseEnabled     MessageType = "MatchPauseEnabled"
+	TypeMatchPauseDisabled    MessageType = "MatchPauseDisabled"
+	TypeRoundStats            MessageType = "RoundStats"
+	TypeWarmupStart           MessageType = "WarmupStart"
+	TypeWarmupEnd             MessageType = "WarmupEnd"
+	TypeLoadingMap            MessageType = "LoadingMap"
+	TypeStartedMap            MessageType = "StartedMap"
+	TypeLogFileStarted        MessageType = "LogFileStarted"
+	TypeLogFileClosed         MessageType = "LogFileClosed"
+	TypePlayerHostageTouched  MessageType = "PlayerHostageTouched"
+	TypePlayerHostageRescued  MessageType = "PlayerHostageRescued"
+	TypePlayerHostageKilled   MessageType = "PlayerHostageKilled"
+	TypePlayerNameChanged     MessageType = "PlayerNameChanged"
+	TypePlayerValidated       MessageType = "PlayerValidated"
+	TypePlayerKicked          MessageType = "PlayerKicked"
+	TypeTeamPlaying           MessageType = "TeamPlaying"
+	TypeMatchStatusScore      MessageType = "MatchStatusScore"
+	TypeAccolade              MessageType = "Accolade"
+	TypePlayerBombBeginPlant  MessageType = "PlayerBombBeginPlant"
+	TypeGrenadeDetonated      MessageType = "GrenadeDetonated"
+	TypePlayerLeftBuyzone     MessageType = "PlayerLeftBuyzone"
+	TypeWorldNotice           MessageType = "WorldNotice"
+	TypeUnknown               MessageType = "Unknown"
+)
+
+// String returns the name of the type
+func (t MessageType) String() string {
+	return string(t)
+}
+
+// Is returns true if m is a message of type t
+func (t MessageType) Is(m Message) bool {
+	return m != nil && m.GetType() == string(t)
+}
+
+// TypeOf returns the type of a message
+func TypeOf(m Message) MessageType {
+	if m == nil {
+		return ""
+	}
+	return MessageType(m.GetType())
+}
+
+// MessageTypes returns all known message types sorted by name,
+// including types added by RegisterType
+func MessageTypes() []MessageType {
+	types.RLock()
+	defer types.RUnlock()
+
+	list := make([]MessageType, 0, len(types.m))
+
+	for t := range types.m {
+		list = append(list, t)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i] < list[j]
+	})
+
+	return list
+}
+
+// TypeSwitch maps message types to functions handling messages of the type
+//
+//	csgolog.TypeSwitch{
+//		csgolog.TypePlayerKill: func(m csgolog.Message) { ... },
+//	}.Handle(m)
+type TypeSwitch map[MessageType]func(Message)
+
+// Handle calls the function for the type of m, it returns
+// false if there is no function for the type
+func (s TypeSwitch) Handle(m Message) bool {
+	fn, ok := s[TypeOf(m)]
+
+	if !ok || fn == nil {
+		return false
+	}
+
+	fn(m)
+
+	return true
+}
@@ -0,0 +1,85 @@
+package csgolog
+
+import (
+	"testing"
+)
+
+func TestMessageType(t *testing.T) {
+
+	t.Run("type of messages", func(t *testing.T) {
+
+		// given
+		known := map[MessageType]bool{}
+		for _, typ := range MessageTypes() {
+			known[typ] = true
+		}
+
+		for _, l := range messageLines {
+
+			// when
+			m, err := Parse(line(l))
+
+			// then
+			assert(t, nil, err)
+			assert(t, true, known[TypeOf(m)])
+			assert(t, true, TypeOf(m).Is(m))
+		}
+	})
+
+	t.Run("constant", func(t *testing.T) {
+
+		// when
+		m, _ := Parse(line(`"Player-Name<12><STEAM_1:1:0101011><CT>" purchased "m4a1"`))
+
+		// then
+		assert(t, TypePlayerPurchase, TypeOf(m))
+		assert(t, TypePlayerPurchase, m.(PlayerPurchase).Type)
+		assert(t, "PlayerPurchase", TypePlayerPurchase.String())
+		assert(t, false, TypePlayerKill.Is(m))
+		assert(t, false, TypePlayerKill.Is(nil))
+	})
+
+	t.Run("message types", func(t *testing.T) {
+
+		// when
+		list := MessageTypes()
+
+		// then
+		assert(t, true, len(list) >= 38)
+
+		for i := 1; i < len(list); i++ {
+			assert(t, true, list[i-1] < list[i])
+		}
+	})
+
+	t.Run("type switch", func(t *testing.T) {
+
+		// given
+		var kills, purchases int
+		s := TypeSwitch{
+			TypePlayerKill: func(Message) {
+				kills++
+			},
+			TypePlayerPurchase: func(m Message) {
+				purchases++
+				assert(t, "m4a1", m.(PlayerPurchase).Item)
+			},
+		}
+
+		// when
+		m, _ := Parse(line(`"Player-Name<12><STEAM_1:1:0101011><CT>" purchased "m4a1"`))
+		handled := s.Handle(m)
+
+		// then
+		assert(t, true, handled)
+		assert(t, 0, kills)
+		assert(t, 1, purchases)
+
+		// when
+		m, _ = Parse(line(`Starting Freeze period`))
+		handled = s.Handle(m)
+
+		// then
+		assert(t, false, handled)
+	})
+}